@@ -1,12 +1,18 @@
 package cmd
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
+	"io"
 	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
 	"time"
 
 	"github.com/peterpisarcik/ionos-cloud-watchdog/internal/config"
+	"github.com/peterpisarcik/ionos-cloud-watchdog/internal/ionos"
+	"github.com/peterpisarcik/ionos-cloud-watchdog/internal/notify"
 	"github.com/peterpisarcik/ionos-cloud-watchdog/internal/output"
 	"github.com/spf13/cobra"
 )
@@ -15,13 +21,19 @@ var (
 	kubeconfig string
 	namespace  string
 	outputFmt  string
+	outputFile string
 	verbose    bool
 	watch      int
+	timeout    time.Duration
+	policy     config.PolicyConfig
+	timeouts   config.TimeoutsConfig
+	kubeCfg    config.KubernetesConfig
+	notifier   *notify.Dispatcher
 
 	runChecksFunc = output.RunChecks
 	printTextFunc = output.PrintText
 	exitFunc      = os.Exit
-	sleepFunc     = time.Sleep
+	sleepFunc     = sleepContext
 )
 
 var rootCmd = &cobra.Command{
@@ -30,33 +42,63 @@ var rootCmd = &cobra.Command{
 	Long: `ionos-cloud-watchdog performs health checks on IONOS Cloud infrastructure
 and Kubernetes clusters, reporting issues with exit codes:
   0 - OK
-  1 - WARNING (1-3 issues)
-  2 - CRITICAL (>3 issues)
+  1 - WARNING (at least one warning issue)
+  2 - CRITICAL (at least one critical issue)
+
+The severity of individual issues, how the overall status is computed and
+the exit codes can be customised in the policy section of the config file.
 
 Configuration:
   Config file: ~/.ionos-cloud-watchdog/config.yaml
   Priority: config file < environment variables < command-line flags
 
 Environment variables:
-  IONOS_TOKEN      IONOS Cloud API token
-  IONOS_USERNAME   IONOS Cloud username (alternative to token)
-  IONOS_PASSWORD   IONOS Cloud password (alternative to token)`,
+  IONOS_TOKEN           IONOS Cloud API token
+  IONOS_USERNAME        IONOS Cloud username (alternative to token)
+  IONOS_PASSWORD        IONOS Cloud password (alternative to token)
+  IONOS_CONCURRENCY     Parallel IONOS API requests per check (default: 8)
+  IONOS_INVENTORY_MODE  per-resource (default) or depth`,
 	RunE: runChecks,
 }
 
 func Execute() error {
-	return rootCmd.Execute()
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	return rootCmd.ExecuteContext(ctx)
 }
 
 func init() {
 	rootCmd.PersistentFlags().StringVar(&kubeconfig, "kubeconfig", "", "path to kubeconfig file")
 	rootCmd.PersistentFlags().StringVarP(&namespace, "namespace", "n", "", "kubernetes namespace to check (default: all)")
-	rootCmd.PersistentFlags().StringVarP(&outputFmt, "output", "o", "text", "output format: text or json")
+	rootCmd.PersistentFlags().StringVarP(&outputFmt, "output", "o", "text", "output format: text, json, html, markdown, junit, nagios, checkmk or zabbix-lld")
+	rootCmd.PersistentFlags().StringVar(&outputFile, "output-file", "", "write the report to a file instead of stdout")
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "verbose output")
 	rootCmd.PersistentFlags().IntVarP(&watch, "watch", "w", 0, "watch mode: refresh interval in seconds (0 = disabled)")
+	rootCmd.PersistentFlags().DurationVar(&timeout, "timeout", 0, "overall timeout for a check run, e.g. 90s (0 = no limit)")
 }
 
 func runChecks(cmd *cobra.Command, args []string) error {
+	if err := loadConfig(time.Duration(watch) * time.Second); err != nil {
+		if outputFmt == "nagios" {
+			exitUnknown(err)
+			return nil
+		}
+		return err
+	}
+
+	if watch > 0 {
+		runWatchMode(cmd.Context())
+	} else {
+		runCheckOnce(cmd.Context(), false)
+	}
+
+	return nil
+}
+
+// loadConfig applies the config file. interval is the time between runs in
+// watch and serve mode.
+func loadConfig(interval time.Duration) error {
 	fileCfg, err := config.Load()
 	if err != nil {
 		return fmt.Errorf("error loading config: %w", err)
@@ -76,24 +118,58 @@ func runChecks(cmd *cobra.Command, args []string) error {
 	if fileCfg.IONOS.APIURL != "" && os.Getenv("IONOS_API_URL") == "" {
 		_ = os.Setenv("IONOS_API_URL", fileCfg.IONOS.APIURL)
 	}
+	if fileCfg.IONOS.Concurrency > 0 && os.Getenv("IONOS_CONCURRENCY") == "" {
+		_ = os.Setenv("IONOS_CONCURRENCY", strconv.Itoa(fileCfg.IONOS.Concurrency))
+	}
+	if fileCfg.IONOS.InventoryMode != "" && os.Getenv("IONOS_INVENTORY_MODE") == "" {
+		_ = os.Setenv("IONOS_INVENTORY_MODE", fileCfg.IONOS.InventoryMode)
+	}
+	if err := ionos.ValidateEnv(); err != nil {
+		return err
+	}
 
 	if kubeconfig == "" && fileCfg.Kubeconfig != "" {
 		kubeconfig = fileCfg.Kubeconfig
 	}
 
-	if watch > 0 {
-		runWatchMode()
-	} else {
-		runCheckOnce(false)
-	}
+	policy = fileCfg.Policy
+	timeouts = fileCfg.Timeouts
+	kubeCfg = fileCfg.Kubernetes
+	notifier = notify.New(fileCfg.Notifications, interval)
 
 	return nil
 }
 
-func runWatchMode() {
+func checkOptions() output.Options {
+	return output.Options{
+		Kubeconfig: kubeconfig,
+		Namespace:  namespace,
+		Policy:     policy,
+		Timeouts: output.Timeouts{
+			StatusPage: timeouts.StatusPage,
+			IONOS:      timeouts.IONOS,
+			Kubernetes: timeouts.Kubernetes,
+		},
+		Kubernetes: kubeCfg,
+	}
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func runWatchMode(ctx context.Context) {
 	first := true
 	for {
-		if outputFmt == "text" {
+		if outputFmt == "text" && outputFile == "" {
 			if !first {
 				fmt.Print("\033[H\033[2J")
 			}
@@ -104,39 +180,101 @@ func runWatchMode() {
 				fmt.Printf("Last check: %s\n\n", time.Now().Format("2006-01-02 15:04:05"))
 			}
 		}
-		runCheckOnce(true)
+		runCheckOnce(ctx, true)
 		first = false
-		sleepFunc(time.Duration(watch) * time.Second)
+		if err := sleepFunc(ctx, time.Duration(watch)*time.Second); err != nil {
+			return
+		}
 	}
 }
 
-func runCheckOnce(watchMode bool) {
-	report, err := runChecksFunc(kubeconfig, namespace)
+func runCheckOnce(ctx context.Context, watchMode bool) {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	report, err := runChecksFunc(ctx, checkOptions())
 	if err != nil {
+		if outputFmt == "nagios" && !watchMode {
+			exitUnknown(err)
+			return
+		}
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		if !watchMode {
 			exitFunc(1)
 		}
 		return
 	}
-
-	if outputFmt == "json" {
-		enc := json.NewEncoder(os.Stdout)
-		enc.SetIndent("", "  ")
-		_ = enc.Encode(report)
-	} else {
-		outputCfg := &output.Config{
-			Verbose: verbose,
+	if outputFmt == "nagios" && !watchMode {
+		if err := report.SetupError(); err != nil {
+			exitUnknown(err)
+			return
 		}
-		printTextFunc(report, outputCfg)
 	}
 
-	if !watchMode {
-		switch report.Status {
-		case "CRITICAL":
-			exitFunc(2)
-		case "WARNING":
-			exitFunc(1)
+	if err := writeReport(report); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+	}
+
+	if watchMode {
+		notifyReport(ctx, report)
+		return
+	}
+
+	if code := output.ExitCode(report.Status, policy); code != 0 {
+		exitFunc(code)
+	}
+}
+
+// writeReport prints the report in the selected format to stdout, or to
+// --output-file if set.
+func writeReport(report *output.Report) (err error) {
+	out := io.Writer(os.Stdout)
+	if outputFile != "" {
+		f, createErr := os.Create(outputFile) //nolint:gosec // path is given by the user
+		if createErr != nil {
+			return fmt.Errorf("error creating output file: %w", createErr)
 		}
+		defer func() {
+			if cerr := f.Close(); err == nil {
+				err = cerr
+			}
+		}()
+		out = f
+	}
+
+	switch outputFmt {
+	case "json":
+		return output.WriteJSON(out, report)
+	case "junit":
+		return output.WriteJUnit(out, report)
+	case "html":
+		return output.WriteHTML(out, report)
+	case "markdown":
+		return output.WriteMarkdown(out, report, &output.Config{Verbose: verbose})
+	case "nagios":
+		return output.WriteNagios(out, report)
+	case "checkmk":
+		return output.WriteCheckmk(out, report)
+	case "zabbix-lld":
+		return output.WriteZabbixLLD(out, report)
+	default:
+		printTextFunc(report, &output.Config{Verbose: verbose, Writer: out})
+		return nil
+	}
+}
+
+// exitUnknown reports a check that could not run as UNKNOWN, following the
+// Nagios plugin convention.
+func exitUnknown(err error) {
+	_ = output.WriteNagiosUnknown(os.Stdout, err)
+	exitFunc(output.ExitCode("UNKNOWN", policy))
+}
+
+func notifyReport(ctx context.Context, report *output.Report) {
+	if err := notifier.Dispatch(ctx, report); err != nil {
+		fmt.Fprintf(os.Stderr, "Notification error: %v\n", err)
 	}
 }
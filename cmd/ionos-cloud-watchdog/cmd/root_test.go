@@ -2,13 +2,20 @@ package cmd
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/peterpisarcik/ionos-cloud-watchdog/internal/config"
+	"github.com/peterpisarcik/ionos-cloud-watchdog/internal/notify"
 	"github.com/peterpisarcik/ionos-cloud-watchdog/internal/output"
 )
 
@@ -18,15 +25,15 @@ func TestRunCheckOnce_JSONAndExitCodes(t *testing.T) {
 	exitFunc = func(code int) { exitCodes = append(exitCodes, code) }
 
 	outputFmt = "json"
-	runChecksFunc = func(kc, ns string) (*output.Report, error) {
+	runChecksFunc = func(_ context.Context, _ output.Options) (*output.Report, error) {
 		return &output.Report{Status: "WARNING"}, nil
 	}
 
 	out := captureStdout(t, func() {
-		runCheckOnce(false)
+		runCheckOnce(context.Background(), false)
 	})
 
-	if !strings.Contains(out, `"Status": "WARNING"`) {
+	if !strings.Contains(out, `"status": "WARNING"`) {
 		t.Fatalf("expected JSON output with status, got: %s", out)
 	}
 	if len(exitCodes) != 1 || exitCodes[0] != 1 {
@@ -38,26 +45,48 @@ func TestRunCheckOnce_TextUsesPrinter(t *testing.T) {
 	defer restoreGlobals()
 	called := false
 	printTextFunc = func(r *output.Report, cfg *output.Config) { called = true }
-	runChecksFunc = func(kc, ns string) (*output.Report, error) {
+	runChecksFunc = func(_ context.Context, _ output.Options) (*output.Report, error) {
 		return &output.Report{Status: "OK"}, nil
 	}
 	outputFmt = "text"
 
-	runCheckOnce(false)
+	runCheckOnce(context.Background(), false)
 
 	if !called {
 		t.Fatalf("expected printTextFunc to be called")
 	}
 }
 
+func TestRunCheckOnce_WritesOutputFile(t *testing.T) {
+	defer restoreGlobals()
+	runChecksFunc = func(_ context.Context, _ output.Options) (*output.Report, error) {
+		return &output.Report{Status: "OK"}, nil
+	}
+	outputFmt = "html"
+	outputFile = filepath.Join(t.TempDir(), "report.html")
+
+	stdout := captureStdout(t, func() { runCheckOnce(context.Background(), false) })
+	if stdout != "" {
+		t.Fatalf("expected nothing on stdout, got %q", stdout)
+	}
+
+	data, err := os.ReadFile(outputFile)
+	if err != nil {
+		t.Fatalf("reading output file: %v", err)
+	}
+	if !strings.Contains(string(data), "<title>IONOS Cloud Watchdog: OK</title>") {
+		t.Fatalf("unexpected output file content:\n%s", data)
+	}
+}
+
 func TestRunCheckOnce_ErrorPrintsAndExits(t *testing.T) {
 	defer restoreGlobals()
 	exitCodes := []int{}
 	exitFunc = func(code int) { exitCodes = append(exitCodes, code) }
 
 	stderr := captureStderr(t, func() {
-		runChecksFunc = func(_, _ string) (*output.Report, error) { return nil, errors.New("boom") }
-		runCheckOnce(false)
+		runChecksFunc = func(_ context.Context, _ output.Options) (*output.Report, error) { return nil, errors.New("boom") }
+		runCheckOnce(context.Background(), false)
 	})
 
 	if len(exitCodes) != 1 || exitCodes[0] != 1 {
@@ -68,13 +97,62 @@ func TestRunCheckOnce_ErrorPrintsAndExits(t *testing.T) {
 	}
 }
 
+func TestRunCheckOnce_NagiosOutput(t *testing.T) {
+	defer restoreGlobals()
+	exitCodes := []int{}
+	exitFunc = func(code int) { exitCodes = append(exitCodes, code) }
+	outputFmt = "nagios"
+
+	runChecksFunc = func(_ context.Context, _ output.Options) (*output.Report, error) {
+		return &output.Report{Status: "CRITICAL", Issues: []output.Issue{{Severity: output.SeverityCritical, Message: "IONOS API unreachable"}}}, nil
+	}
+	out := captureStdout(t, func() { runCheckOnce(context.Background(), false) })
+	if !strings.HasPrefix(out, "IONOS WATCHDOG CRITICAL - 1 critical: IONOS API unreachable | ") {
+		t.Fatalf("unexpected nagios output: %s", out)
+	}
+
+	runChecksFunc = func(_ context.Context, _ output.Options) (*output.Report, error) { return nil, errors.New("boom") }
+	out = captureStdout(t, func() { runCheckOnce(context.Background(), false) })
+	if out != "IONOS WATCHDOG UNKNOWN - boom\n" {
+		t.Fatalf("unexpected unknown output: %q", out)
+	}
+
+	if len(exitCodes) != 2 || exitCodes[0] != 2 || exitCodes[1] != 3 {
+		t.Fatalf("expected exit codes [2 3], got %v", exitCodes)
+	}
+}
+
+func TestRunCheckOnce_NagiosUnknownWhenSubsystemCouldNotRun(t *testing.T) {
+	defer restoreGlobals()
+	exitCodes := []int{}
+	exitFunc = func(code int) { exitCodes = append(exitCodes, code) }
+	outputFmt = "nagios"
+
+	runChecksFunc = func(_ context.Context, _ output.Options) (*output.Report, error) {
+		return &output.Report{Status: "CRITICAL", Issues: []output.Issue{{
+			Severity:  output.SeverityCritical,
+			Check:     output.CheckSetup,
+			Subsystem: output.SubsystemKubernetes,
+			Message:   "Kubernetes check could not run: failed to load kubeconfig",
+		}}}, nil
+	}
+	out := captureStdout(t, func() { runCheckOnce(context.Background(), false) })
+
+	if out != "IONOS WATCHDOG UNKNOWN - Kubernetes check could not run: failed to load kubeconfig\n" {
+		t.Fatalf("unexpected unknown output: %q", out)
+	}
+	if len(exitCodes) != 1 || exitCodes[0] != 3 {
+		t.Fatalf("expected exit code 3, got %v", exitCodes)
+	}
+}
+
 func TestRootCommandExecutesRunChecks(t *testing.T) {
 	defer restoreGlobals()
 	exitCodes := []int{}
 	exitFunc = func(code int) { exitCodes = append(exitCodes, code) }
 	outputFmt = "json"
 
-	runChecksFunc = func(_, _ string) (*output.Report, error) {
+	runChecksFunc = func(_ context.Context, _ output.Options) (*output.Report, error) {
 		return &output.Report{Status: "OK"}, nil
 	}
 
@@ -92,12 +170,21 @@ func restoreGlobals() {
 	runChecksFunc = output.RunChecks
 	printTextFunc = output.PrintText
 	exitFunc = os.Exit
-	sleepFunc = func(d time.Duration) { time.Sleep(d) }
+	sleepFunc = sleepContext
 	outputFmt = "text"
+	outputFile = ""
 	verbose = false
 	kubeconfig = ""
 	namespace = ""
 	watch = 0
+	timeout = 0
+	policy = config.PolicyConfig{}
+	timeouts = config.TimeoutsConfig{}
+	kubeCfg = config.KubernetesConfig{}
+	notifier = nil
+	listenAddr = ":9101"
+	serveInterval = 60
+	listenAndServeFunc = func(server *http.Server) error { return server.ListenAndServe() }
 }
 
 func captureStdout(t *testing.T, fn func()) string {
@@ -129,16 +216,133 @@ func captureStderr(t *testing.T, fn func()) string {
 func TestJSONOutputIsIndented(t *testing.T) {
 	defer restoreGlobals()
 	outputFmt = "json"
-	runChecksFunc = func(_, _ string) (*output.Report, error) {
+	runChecksFunc = func(_ context.Context, _ output.Options) (*output.Report, error) {
 		return &output.Report{Status: "OK"}, nil
 	}
 
 	out := captureStdout(t, func() {
-		runCheckOnce(false)
+		runCheckOnce(context.Background(), false)
 	})
 
-	var decoded output.Report
+	var decoded output.JSONReport
 	if err := json.Unmarshal([]byte(out), &decoded); err != nil {
 		t.Fatalf("expected valid json output: %v\n%s", err, out)
 	}
+	if decoded.SchemaVersion != output.SchemaVersion {
+		t.Fatalf("expected schema_version %q, got %q", output.SchemaVersion, decoded.SchemaVersion)
+	}
+}
+
+func TestRunCheckOnce_PolicyExitCodes(t *testing.T) {
+	defer restoreGlobals()
+	exitCodes := []int{}
+	exitFunc = func(code int) { exitCodes = append(exitCodes, code) }
+	printTextFunc = func(r *output.Report, cfg *output.Config) {}
+	policy = config.PolicyConfig{ExitCodes: map[string]int{"warning": 0, "critical": 3}}
+
+	runChecksFunc = func(_ context.Context, _ output.Options) (*output.Report, error) {
+		return &output.Report{Status: "WARNING"}, nil
+	}
+	runCheckOnce(context.Background(), false)
+
+	runChecksFunc = func(_ context.Context, _ output.Options) (*output.Report, error) {
+		return &output.Report{Status: "CRITICAL"}, nil
+	}
+	runCheckOnce(context.Background(), false)
+
+	if len(exitCodes) != 1 || exitCodes[0] != 3 {
+		t.Fatalf("expected only the configured critical exit code 3, got %v", exitCodes)
+	}
+}
+
+func TestRunCheckOnce_AppliesTimeouts(t *testing.T) {
+	defer restoreGlobals()
+	printTextFunc = func(r *output.Report, cfg *output.Config) {}
+	timeout = time.Minute
+	timeouts = config.TimeoutsConfig{IONOS: 30 * time.Second}
+	kubeCfg = config.KubernetesConfig{Events: config.EventsConfig{Lookback: 10 * time.Minute}}
+
+	var gotDeadline bool
+	var gotOpts output.Options
+	runChecksFunc = func(ctx context.Context, opts output.Options) (*output.Report, error) {
+		_, gotDeadline = ctx.Deadline()
+		gotOpts = opts
+		return &output.Report{Status: "OK"}, nil
+	}
+
+	runCheckOnce(context.Background(), false)
+
+	if !gotDeadline {
+		t.Fatalf("expected --timeout to set a deadline on the context")
+	}
+	if gotOpts.Timeouts.IONOS != 30*time.Second {
+		t.Fatalf("expected IONOS timeout from config, got %s", gotOpts.Timeouts.IONOS)
+	}
+	if gotOpts.Kubernetes.Events.Lookback != 10*time.Minute {
+		t.Fatalf("expected event lookback from config, got %s", gotOpts.Kubernetes.Events.Lookback)
+	}
+}
+
+func TestRunWatchMode_StopsWhenContextCancelled(t *testing.T) {
+	defer restoreGlobals()
+	watch = 1
+	outputFmt = "json"
+
+	ctx, cancel := context.WithCancel(context.Background())
+	runs := 0
+	runChecksFunc = func(_ context.Context, _ output.Options) (*output.Report, error) {
+		runs++
+		cancel()
+		return &output.Report{Status: "OK"}, nil
+	}
+
+	_ = captureStdout(t, func() {
+		runWatchMode(ctx)
+	})
+
+	if runs != 1 {
+		t.Fatalf("expected a single run before cancellation, got %d", runs)
+	}
+}
+
+func TestRunWatchMode_NotifiesOnStatusChange(t *testing.T) {
+	defer restoreGlobals()
+	watch = 1
+	outputFmt = "json"
+
+	var payloads []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		payloads = append(payloads, string(body))
+	}))
+	defer server.Close()
+	notifier = notify.NewDispatcher(0, &notify.WebhookNotifier{URL: server.URL, Client: server.Client()})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	statuses := []string{"OK", "CRITICAL", "CRITICAL"}
+	runs := 0
+	runChecksFunc = func(_ context.Context, _ output.Options) (*output.Report, error) {
+		status := statuses[runs]
+		runs++
+		if runs == len(statuses) {
+			cancel()
+		}
+		report := &output.Report{Status: status}
+		if status == "CRITICAL" {
+			report.Issues = []output.Issue{{Severity: output.SeverityCritical, Check: output.CheckAuth, Message: "IONOS authentication failed"}}
+		}
+		return report, nil
+	}
+	sleepFunc = func(ctx context.Context, _ time.Duration) error { return ctx.Err() }
+
+	_ = captureStdout(t, func() {
+		runWatchMode(ctx)
+	})
+
+	if len(payloads) != 1 {
+		t.Fatalf("expected a single notification, got %d", len(payloads))
+	}
+	if !strings.Contains(payloads[0], `"event":"status_changed"`) {
+		t.Fatalf("unexpected payload: %s", payloads[0])
+	}
 }
@@ -0,0 +1,27 @@
+package cmd
+
+import (
+	"github.com/peterpisarcik/ionos-cloud-watchdog/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var schemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "Print the JSON Schema of the JSON output",
+	Long: `Print the JSON Schema describing the output of -o json.
+
+The schema_version field of the output changes whenever a field is renamed,
+removed or changes its meaning.
+
+Examples:
+  ionos-cloud-watchdog schema > report.schema.json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		_, err := cmd.OutOrStdout().Write(output.Schema())
+		return err
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(schemaCmd)
+}
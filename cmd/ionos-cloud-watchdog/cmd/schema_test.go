@@ -0,0 +1,28 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestSchemaCommandPrintsSchema(t *testing.T) {
+	defer restoreGlobals()
+
+	var out bytes.Buffer
+	rootCmd.SetOut(&out)
+	defer rootCmd.SetOut(nil)
+
+	rootCmd.SetArgs([]string{"schema"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("Execute returned error: %v", err)
+	}
+
+	var schema map[string]any
+	if err := json.Unmarshal(out.Bytes(), &schema); err != nil {
+		t.Fatalf("expected valid json schema: %v\n%s", err, out.String())
+	}
+	if schema["$schema"] == nil {
+		t.Fatalf("expected $schema keyword, got: %v", schema)
+	}
+}
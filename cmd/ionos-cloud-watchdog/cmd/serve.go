@@ -0,0 +1,143 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/peterpisarcik/ionos-cloud-watchdog/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var (
+	listenAddr    string
+	serveInterval int
+
+	listenAndServeFunc = func(server *http.Server) error { return server.ListenAndServe() }
+)
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Run checks periodically and expose results as Prometheus metrics",
+	Long: `Run all checks on a fixed interval and expose the latest results on /metrics
+in Prometheus exposition format.
+
+Examples:
+  # Listen on the default address and refresh every 60 seconds
+  ionos-cloud-watchdog serve
+
+  # Custom listen address and interval
+  ionos-cloud-watchdog serve --listen :9200 --interval 120`,
+	RunE: runServe,
+}
+
+func init() {
+	serveCmd.Flags().StringVar(&listenAddr, "listen", ":9101", "address to expose metrics on")
+	serveCmd.Flags().IntVar(&serveInterval, "interval", 60, "check interval in seconds")
+
+	rootCmd.AddCommand(serveCmd)
+}
+
+type exporter struct {
+	mu     sync.RWMutex
+	report *output.Report
+	stats  output.RunStats
+}
+
+func runServe(cmd *cobra.Command, args []string) error {
+	if err := loadConfig(time.Duration(serveInterval) * time.Second); err != nil {
+		return err
+	}
+
+	if serveInterval <= 0 {
+		return fmt.Errorf("interval must be greater than 0")
+	}
+
+	ctx := cmd.Context()
+
+	exp := &exporter{}
+	go func() {
+		for {
+			exp.collect(ctx)
+			if err := sleepFunc(ctx, time.Duration(serveInterval)*time.Second); err != nil {
+				return
+			}
+		}
+	}()
+
+	server := &http.Server{
+		Addr:              listenAddr,
+		Handler:           exp.handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+	}()
+
+	fmt.Printf("Serving metrics on %s/metrics\n", listenAddr)
+	if err := listenAndServeFunc(server); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+func (e *exporter) collect(ctx context.Context) {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	start := time.Now()
+	report, err := runChecksFunc(ctx, checkOptions())
+	duration := time.Since(start)
+
+	if err == nil {
+		notifyReport(ctx, report)
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.stats.Runs++
+	e.stats.LastRun = time.Now()
+	e.stats.Duration = duration
+	e.stats.Success = err == nil
+
+	if err != nil {
+		e.stats.Failures++
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return
+	}
+
+	e.report = report
+}
+
+func (e *exporter) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/metrics", e.serveMetrics)
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintln(w, "ok")
+	})
+	return mux
+}
+
+func (e *exporter) serveMetrics(w http.ResponseWriter, r *http.Request) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+
+	output.WriteRunMetrics(w, e.stats)
+	if e.report != nil {
+		output.WriteMetrics(w, e.report)
+	}
+}
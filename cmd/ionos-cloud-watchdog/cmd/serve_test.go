@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/peterpisarcik/ionos-cloud-watchdog/internal/output"
+)
+
+func TestExporterServesLatestReport(t *testing.T) {
+	defer restoreGlobals()
+	runChecksFunc = func(_ context.Context, _ output.Options) (*output.Report, error) {
+		return &output.Report{Status: "CRITICAL", Issues: []output.Issue{
+			{Severity: output.SeverityCritical, Message: "a"},
+			{Severity: output.SeverityWarning, Message: "b"},
+		}}, nil
+	}
+
+	exp := &exporter{}
+	exp.collect(context.Background())
+
+	server := httptest.NewServer(exp.handler())
+	defer server.Close()
+
+	body := getBody(t, server.URL+"/metrics")
+
+	if !strings.Contains(body, `ionos_watchdog_status{status="CRITICAL"} 1`) {
+		t.Fatalf("expected status metric, got:\n%s", body)
+	}
+	if !strings.Contains(body, `ionos_watchdog_issues{severity="critical"} 1`) {
+		t.Fatalf("expected issues metric, got:\n%s", body)
+	}
+	if !strings.Contains(body, "ionos_watchdog_last_run_success 1") {
+		t.Fatalf("expected run metrics, got:\n%s", body)
+	}
+}
+
+func TestExporterKeepsPreviousReportOnError(t *testing.T) {
+	defer restoreGlobals()
+	runChecksFunc = func(_ context.Context, _ output.Options) (*output.Report, error) {
+		return &output.Report{Status: "OK"}, nil
+	}
+
+	exp := &exporter{}
+	exp.collect(context.Background())
+
+	runChecksFunc = func(_ context.Context, _ output.Options) (*output.Report, error) { return nil, errors.New("boom") }
+	_ = captureStderr(t, func() { exp.collect(context.Background()) })
+
+	server := httptest.NewServer(exp.handler())
+	defer server.Close()
+
+	body := getBody(t, server.URL+"/metrics")
+
+	if !strings.Contains(body, `ionos_watchdog_status{status="OK"} 1`) {
+		t.Fatalf("expected previous report to be served, got:\n%s", body)
+	}
+	if !strings.Contains(body, "ionos_watchdog_run_failures_total 1") {
+		t.Fatalf("expected failure counter, got:\n%s", body)
+	}
+	if !strings.Contains(body, "ionos_watchdog_last_run_success 0") {
+		t.Fatalf("expected last run to be marked failed, got:\n%s", body)
+	}
+}
+
+func TestServeCommandRejectsInvalidInterval(t *testing.T) {
+	defer restoreGlobals()
+	listenAndServeFunc = func(_ *http.Server) error {
+		t.Fatalf("server should not start")
+		return nil
+	}
+
+	rootCmd.SetArgs([]string{"serve", "--interval", "0"})
+	if err := rootCmd.Execute(); err == nil {
+		t.Fatalf("expected error for invalid interval")
+	}
+}
+
+func getBody(t *testing.T, url string) string {
+	t.Helper()
+	resp, err := http.Get(url) //nolint:gosec // test server URL
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("failed to read body: %v", err)
+	}
+	return string(body)
+}
@@ -4,22 +4,126 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
 
 type Config struct {
-	IONOS      IONOSConfig `yaml:"ionos"`
-	Kubeconfig string      `yaml:"kubeconfig,omitempty"`
+	IONOS      IONOSConfig    `yaml:"ionos"`
+	Kubeconfig string         `yaml:"kubeconfig,omitempty"`
+	Policy     PolicyConfig   `yaml:"policy,omitempty"`
+	Timeouts   TimeoutsConfig `yaml:"timeouts,omitempty"`
+
+	Kubernetes KubernetesConfig `yaml:"kubernetes,omitempty"`
+
+	Notifications NotificationsConfig `yaml:"notifications,omitempty"`
 }
 
 type IONOSConfig struct {
-	Token    string `yaml:"token,omitempty"`
-	Username string `yaml:"username,omitempty"`
-	Password string `yaml:"password,omitempty"`
-	APIURL   string `yaml:"api_url,omitempty"`
+	Token         string `yaml:"token,omitempty"`
+	Username      string `yaml:"username,omitempty"`
+	Password      string `yaml:"password,omitempty"`
+	APIURL        string `yaml:"api_url,omitempty"`
+	Concurrency   int    `yaml:"concurrency,omitempty"`
+	InventoryMode string `yaml:"inventory_mode,omitempty"`
+}
+
+type TimeoutsConfig struct {
+	StatusPage time.Duration `yaml:"status_page,omitempty"`
+	IONOS      time.Duration `yaml:"ionos,omitempty"`
+	Kubernetes time.Duration `yaml:"kubernetes,omitempty"`
+}
+
+type KubernetesConfig struct {
+	Events EventsConfig `yaml:"events,omitempty"`
+	Pods   PodsConfig   `yaml:"pods,omitempty"`
+}
+
+type EventsConfig struct {
+	Lookback time.Duration `yaml:"lookback,omitempty"`
+	// IssueReasons are glob patterns of event reasons that are reported as
+	// issues, e.g. FailedMount or BackOff.
+	IssueReasons []string `yaml:"issue_reasons,omitempty"`
+}
+
+type PodsConfig struct {
+	RestartThreshold int           `yaml:"restart_threshold,omitempty"`
+	NotReadyAfter    time.Duration `yaml:"not_ready_after,omitempty"`
+}
+
+type NotificationsConfig struct {
+	ReminderInterval time.Duration        `yaml:"reminder_interval,omitempty"`
+	Webhooks         []WebhookConfig      `yaml:"webhooks,omitempty"`
+	Slack            []SlackConfig        `yaml:"slack,omitempty"`
+	Teams            []TeamsConfig        `yaml:"teams,omitempty"`
+	PagerDuty        []PagerDutyConfig    `yaml:"pagerduty,omitempty"`
+	Opsgenie         []OpsgenieConfig     `yaml:"opsgenie,omitempty"`
+	Alertmanager     []AlertmanagerConfig `yaml:"alertmanager,omitempty"`
+}
+
+type WebhookConfig struct {
+	URL     string            `yaml:"url"`
+	Headers map[string]string `yaml:"headers,omitempty"`
+}
+
+type SlackConfig struct {
+	WebhookURL string `yaml:"webhook_url"`
+}
+
+type TeamsConfig struct {
+	WebhookURL string `yaml:"webhook_url"`
+}
+
+type PagerDutyConfig struct {
+	RoutingKey string `yaml:"routing_key"`
+	URL        string `yaml:"url,omitempty"`
+	Source     string `yaml:"source,omitempty"`
 }
 
+type OpsgenieConfig struct {
+	APIKey string   `yaml:"api_key"`
+	APIURL string   `yaml:"api_url,omitempty"`
+	Source string   `yaml:"source,omitempty"`
+	Tags   []string `yaml:"tags,omitempty"`
+}
+
+type AlertmanagerConfig struct {
+	URL     string            `yaml:"url"`
+	Labels  map[string]string `yaml:"labels,omitempty"`
+	Headers map[string]string `yaml:"headers,omitempty"`
+}
+
+type PolicyConfig struct {
+	Mode              string         `yaml:"mode,omitempty"`
+	WarningThreshold  int            `yaml:"warning_threshold,omitempty"`
+	CriticalThreshold int            `yaml:"critical_threshold,omitempty"`
+	Weights           map[string]int `yaml:"weights,omitempty"`
+	Rules             []SeverityRule `yaml:"rules,omitempty"`
+	ExitCodes         map[string]int `yaml:"exit_codes,omitempty"`
+}
+
+type SeverityRule struct {
+	Check     string `yaml:"check,omitempty"`
+	Subsystem string `yaml:"subsystem,omitempty"`
+	Kind      string `yaml:"kind,omitempty"`
+	Namespace string `yaml:"namespace,omitempty"`
+	Name      string `yaml:"name,omitempty"`
+	Severity  string `yaml:"severity"`
+}
+
+const (
+	PolicyModeWorst    = "worst"
+	PolicyModeCount    = "count"
+	PolicyModeWeighted = "weighted"
+)
+
+var (
+	validSeverities = map[string]bool{"info": true, "warning": true, "critical": true, "ignore": true}
+	validStatuses   = map[string]bool{"ok": true, "warning": true, "critical": true, "unknown": true}
+)
+
 func GetConfigDir() (string, error) {
 	home, err := os.UserHomeDir()
 	if err != nil {
@@ -55,6 +159,28 @@ func Load() (*Config, error) {
 		return nil, fmt.Errorf("failed to parse config file: %w", err)
 	}
 
+	if cfg.IONOS.Concurrency < 0 {
+		return nil, fmt.Errorf("invalid ionos.concurrency: must not be negative")
+	}
+
+	if cfg.Kubernetes.Events.Lookback < 0 {
+		return nil, fmt.Errorf("invalid kubernetes.events.lookback: must not be negative")
+	}
+	if cfg.Kubernetes.Pods.RestartThreshold < 0 {
+		return nil, fmt.Errorf("invalid kubernetes.pods.restart_threshold: must not be negative")
+	}
+	if cfg.Kubernetes.Pods.NotReadyAfter < 0 {
+		return nil, fmt.Errorf("invalid kubernetes.pods.not_ready_after: must not be negative")
+	}
+
+	if err := cfg.Policy.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid policy: %w", err)
+	}
+
+	if err := cfg.Notifications.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid notifications: %w", err)
+	}
+
 	return &cfg, nil
 }
 
@@ -94,4 +220,82 @@ func (c *Config) ApplyEnvironment() {
 	if apiURL := os.Getenv("IONOS_API_URL"); apiURL != "" {
 		c.IONOS.APIURL = apiURL
 	}
+	if concurrency, err := strconv.Atoi(os.Getenv("IONOS_CONCURRENCY")); err == nil && concurrency > 0 {
+		c.IONOS.Concurrency = concurrency
+	}
+	if mode := os.Getenv("IONOS_INVENTORY_MODE"); mode != "" {
+		c.IONOS.InventoryMode = mode
+	}
+}
+
+func (p PolicyConfig) Validate() error {
+	switch p.Mode {
+	case "", PolicyModeWorst, PolicyModeCount, PolicyModeWeighted:
+	default:
+		return fmt.Errorf("unknown mode %q (expected worst, count or weighted)", p.Mode)
+	}
+
+	if p.WarningThreshold < 0 || p.CriticalThreshold < 0 {
+		return fmt.Errorf("thresholds must not be negative")
+	}
+
+	for severity := range p.Weights {
+		if !validSeverities[severity] || severity == "ignore" {
+			return fmt.Errorf("unknown severity %q in weights", severity)
+		}
+	}
+
+	for i, rule := range p.Rules {
+		if !validSeverities[rule.Severity] {
+			return fmt.Errorf("rule %d: unknown severity %q (expected info, warning, critical or ignore)", i+1, rule.Severity)
+		}
+	}
+
+	for status, code := range p.ExitCodes {
+		if !validStatuses[status] {
+			return fmt.Errorf("unknown status %q in exit_codes", status)
+		}
+		if code < 0 || code > 255 {
+			return fmt.Errorf("exit code for %s must be between 0 and 255", status)
+		}
+	}
+
+	return nil
+}
+
+func (n NotificationsConfig) Validate() error {
+	if n.ReminderInterval < 0 {
+		return fmt.Errorf("reminder_interval must not be negative")
+	}
+	for i, webhook := range n.Webhooks {
+		if webhook.URL == "" {
+			return fmt.Errorf("webhook %d: url is required", i+1)
+		}
+	}
+	for i, slack := range n.Slack {
+		if slack.WebhookURL == "" {
+			return fmt.Errorf("slack %d: webhook_url is required", i+1)
+		}
+	}
+	for i, teams := range n.Teams {
+		if teams.WebhookURL == "" {
+			return fmt.Errorf("teams %d: webhook_url is required", i+1)
+		}
+	}
+	for i, pd := range n.PagerDuty {
+		if pd.RoutingKey == "" {
+			return fmt.Errorf("pagerduty %d: routing_key is required", i+1)
+		}
+	}
+	for i, og := range n.Opsgenie {
+		if og.APIKey == "" {
+			return fmt.Errorf("opsgenie %d: api_key is required", i+1)
+		}
+	}
+	for i, am := range n.Alertmanager {
+		if am.URL == "" {
+			return fmt.Errorf("alertmanager %d: url is required", i+1)
+		}
+	}
+	return nil
 }
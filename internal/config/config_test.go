@@ -2,7 +2,9 @@ package config
 
 import (
 	"os"
+	"path/filepath"
 	"testing"
+	"time"
 )
 
 func TestApplyEnvironment(t *testing.T) {
@@ -54,6 +56,18 @@ func TestApplyEnvironment(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "applies concurrency from environment",
+			envVars: map[string]string{
+				"IONOS_CONCURRENCY": "16",
+			},
+			initial: Config{},
+			expected: Config{
+				IONOS: IONOSConfig{
+					Concurrency: 16,
+				},
+			},
+		},
 		{
 			name:    "no environment variables leaves config unchanged",
 			envVars: map[string]string{},
@@ -77,6 +91,7 @@ func TestApplyEnvironment(t *testing.T) {
 			_ = os.Unsetenv("IONOS_USERNAME")
 			_ = os.Unsetenv("IONOS_PASSWORD")
 			_ = os.Unsetenv("IONOS_API_URL")
+			_ = os.Unsetenv("IONOS_CONCURRENCY")
 
 			// Set test environment variables
 			for key, value := range tt.envVars {
@@ -97,6 +112,9 @@ func TestApplyEnvironment(t *testing.T) {
 			if cfg.IONOS.Password != tt.expected.IONOS.Password {
 				t.Errorf("Password = %v, want %v", cfg.IONOS.Password, tt.expected.IONOS.Password)
 			}
+			if cfg.IONOS.Concurrency != tt.expected.IONOS.Concurrency {
+				t.Errorf("Concurrency = %v, want %v", cfg.IONOS.Concurrency, tt.expected.IONOS.Concurrency)
+			}
 
 			// Cleanup
 			for key := range tt.envVars {
@@ -105,3 +123,120 @@ func TestApplyEnvironment(t *testing.T) {
 		})
 	}
 }
+
+func TestLoad_Policy(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	data := []byte(`ionos:
+  token: abc
+policy:
+  mode: count
+  critical_threshold: 10
+  rules:
+    - check: k8s.pods
+      namespace: batch
+      severity: ignore
+  exit_codes:
+    warning: 0
+timeouts:
+  ionos: 90s
+  kubernetes: 1m
+kubernetes:
+  events:
+    lookback: 30m
+    issue_reasons: [FailedMount, BackOff]
+  pods:
+    restart_threshold: 3
+    not_ready_after: 10m
+`)
+	if err := os.MkdirAll(filepath.Join(home, ".ionos-cloud-watchdog"), 0700); err != nil {
+		t.Fatalf("failed to create config dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(home, ".ionos-cloud-watchdog", "config.yaml"), data, 0600); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+
+	if cfg.Policy.Mode != PolicyModeCount || cfg.Policy.CriticalThreshold != 10 {
+		t.Errorf("unexpected policy: %+v", cfg.Policy)
+	}
+	if len(cfg.Policy.Rules) != 1 || cfg.Policy.Rules[0].Severity != "ignore" {
+		t.Errorf("unexpected rules: %+v", cfg.Policy.Rules)
+	}
+	if code, ok := cfg.Policy.ExitCodes["warning"]; !ok || code != 0 {
+		t.Errorf("unexpected exit codes: %+v", cfg.Policy.ExitCodes)
+	}
+	if cfg.Timeouts.IONOS != 90*time.Second || cfg.Timeouts.Kubernetes != time.Minute {
+		t.Errorf("unexpected timeouts: %+v", cfg.Timeouts)
+	}
+	if events := cfg.Kubernetes.Events; events.Lookback != 30*time.Minute || len(events.IssueReasons) != 2 {
+		t.Errorf("unexpected events config: %+v", events)
+	}
+	if pods := cfg.Kubernetes.Pods; pods.RestartThreshold != 3 || pods.NotReadyAfter != 10*time.Minute {
+		t.Errorf("unexpected pods config: %+v", pods)
+	}
+}
+
+func TestPolicyValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  PolicyConfig
+		wantErr bool
+	}{
+		{name: "empty policy", policy: PolicyConfig{}},
+		{name: "weighted with weights", policy: PolicyConfig{Mode: "weighted", Weights: map[string]int{"critical": 5}}},
+		{name: "unknown mode", policy: PolicyConfig{Mode: "average"}, wantErr: true},
+		{name: "negative threshold", policy: PolicyConfig{CriticalThreshold: -1}, wantErr: true},
+		{name: "unknown weight severity", policy: PolicyConfig{Weights: map[string]int{"fatal": 1}}, wantErr: true},
+		{name: "unknown rule severity", policy: PolicyConfig{Rules: []SeverityRule{{Check: "k8s.pods", Severity: "low"}}}, wantErr: true},
+		{name: "unknown exit code status", policy: PolicyConfig{ExitCodes: map[string]int{"degraded": 1}}, wantErr: true},
+		{name: "exit code out of range", policy: PolicyConfig{ExitCodes: map[string]int{"critical": 300}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.policy.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNotificationsValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     NotificationsConfig
+		wantErr bool
+	}{
+		{name: "empty", cfg: NotificationsConfig{}},
+		{name: "all targets", cfg: NotificationsConfig{
+			ReminderInterval: time.Hour,
+			Webhooks:         []WebhookConfig{{URL: "https://example.com/hook"}},
+			Slack:            []SlackConfig{{WebhookURL: "https://hooks.slack.com/services/x"}},
+			Teams:            []TeamsConfig{{WebhookURL: "https://example.webhook.office.com/x"}},
+		}},
+		{name: "negative reminder", cfg: NotificationsConfig{ReminderInterval: -time.Minute}, wantErr: true},
+		{name: "webhook without url", cfg: NotificationsConfig{Webhooks: []WebhookConfig{{}}}, wantErr: true},
+		{name: "slack without url", cfg: NotificationsConfig{Slack: []SlackConfig{{}}}, wantErr: true},
+		{name: "teams without url", cfg: NotificationsConfig{Teams: []TeamsConfig{{}}}, wantErr: true},
+		{name: "pagerduty", cfg: NotificationsConfig{PagerDuty: []PagerDutyConfig{{RoutingKey: "rk"}}}},
+		{name: "pagerduty without routing key", cfg: NotificationsConfig{PagerDuty: []PagerDutyConfig{{}}}, wantErr: true},
+		{name: "opsgenie without api key", cfg: NotificationsConfig{Opsgenie: []OpsgenieConfig{{}}}, wantErr: true},
+		{name: "alertmanager without url", cfg: NotificationsConfig{Alertmanager: []AlertmanagerConfig{{}}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
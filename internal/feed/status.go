@@ -1,6 +1,7 @@
 package feed
 
 import (
+	"context"
 	"encoding/xml"
 	"fmt"
 	"io"
@@ -26,6 +27,8 @@ type Link struct {
 	Href string `xml:"href,attr"`
 }
 
+var statusFeedURL = "https://status.ionos.cloud/history.atom"
+
 type Status string
 
 const (
@@ -35,9 +38,9 @@ const (
 )
 
 type StatusResult struct {
-	Status         Status
+	Status          Status
 	ActiveIncidents []Entry
-	Message        string
+	Message         string
 }
 
 var activeKeywords = []string{
@@ -54,8 +57,13 @@ var resolvedKeywords = []string{
 	"no customer impact",
 }
 
-func CheckStatus() (*StatusResult, error) {
-	resp, err := http.Get("https://status.ionos.cloud/history.atom")
+func CheckStatus(ctx context.Context) (*StatusResult, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", statusFeedURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %w", err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error fetching status page: %w", err)
 	}
@@ -115,7 +123,7 @@ func analyzeEntries(entries []Entry) *StatusResult {
 	}
 
 	result := &StatusResult{
-		Status:         StatusOK,
+		Status:          StatusOK,
 		ActiveIncidents: activeIncidents,
 	}
 
@@ -1,6 +1,10 @@
 package feed
 
 import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 )
@@ -95,3 +99,49 @@ func TestAnalyzeEntries_IgnoresResolved(t *testing.T) {
 		t.Fatalf("expected resolved incident to be ignored")
 	}
 }
+
+func TestCheckStatus_FetchesFeed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom">
+  <title>IONOS Cloud Status</title>
+  <entry>
+    <title>Network latency in FRA</title>
+    <updated>` + time.Now().Format(time.RFC3339) + `</updated>
+    <content>We are investigating connectivity issues</content>
+  </entry>
+</feed>`))
+	}))
+	defer server.Close()
+	setFeedURL(t, server.URL)
+
+	result, err := CheckStatus(context.Background())
+	if err != nil {
+		t.Fatalf("CheckStatus returned error: %v", err)
+	}
+	if result.Status != StatusWarning || len(result.ActiveIncidents) != 1 {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+}
+
+func TestCheckStatus_HonoursContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+	setFeedURL(t, server.URL)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	if _, err := CheckStatus(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got: %v", err)
+	}
+}
+
+func setFeedURL(t *testing.T, url string) {
+	t.Helper()
+	orig := statusFeedURL
+	statusFeedURL = url
+	t.Cleanup(func() { statusFeedURL = orig })
+}
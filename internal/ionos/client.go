@@ -1,11 +1,13 @@
 package ionos
 
 import (
+	"context"
 	"encoding/base64"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"os"
+	"sync"
 	"time"
 )
 
@@ -14,15 +16,25 @@ const (
 )
 
 type Client struct {
-	BaseURL             string
-	PostgreSQLBaseURL   string
-	MongoDBBaseURL      string
-	MariaDBBaseURL      string
-	InMemoryDBBaseURL   string
-	Token               string
-	Username            string
-	Password            string
-	HTTPClient          *http.Client
+	BaseURL           string
+	PostgreSQLBaseURL string
+	MongoDBBaseURL    string
+	MariaDBBaseURL    string
+	InMemoryDBBaseURL string
+	Token             string
+	Username          string
+	Password          string
+	PageSize          int
+	Concurrency       int
+	InventoryMode     string
+	MaxInventoryBytes int64
+	MaxRetries        int
+	RetryWaitMin      time.Duration
+	RetryWaitMax      time.Duration
+	HTTPClient        *http.Client
+
+	mu             sync.Mutex
+	throttledUntil time.Time
 }
 
 type CheckResult struct {
@@ -37,6 +49,10 @@ func NewClientFromEnv() (*Client, error) {
 		MongoDBBaseURL:    "https://api.ionos.com/databases/mongodb",
 		MariaDBBaseURL:    "https://api.ionos.com/databases/mariadb",
 		InMemoryDBBaseURL: "https://api.ionos.com/databases/in-memory-db",
+		MaxRetries:        DefaultMaxRetries,
+		RetryWaitMin:      DefaultRetryWaitMin,
+		RetryWaitMax:      DefaultRetryWaitMax,
+		MaxInventoryBytes: DefaultMaxInventoryBytes,
 		HTTPClient: &http.Client{
 			Timeout: 10 * time.Second,
 		},
@@ -46,6 +62,18 @@ func NewClientFromEnv() (*Client, error) {
 		client.BaseURL = url + "/cloudapi/v6"
 	}
 
+	concurrency, err := concurrencyFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	client.Concurrency = concurrency
+
+	inventoryMode, err := inventoryModeFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	client.InventoryMode = inventoryMode
+
 	if token := os.Getenv("IONOS_TOKEN"); token != "" {
 		client.Token = token
 		return client, nil
@@ -64,13 +92,13 @@ func NewClientFromEnv() (*Client, error) {
 	return client, nil
 }
 
-func (c *Client) CheckConnectivity() CheckResult {
-	req, err := http.NewRequest("GET", c.BaseURL, nil)
+func (c *Client) CheckConnectivity(ctx context.Context) CheckResult {
+	req, err := http.NewRequestWithContext(ctx, "GET", c.BaseURL, nil)
 	if err != nil {
 		return CheckResult{OK: false, Message: fmt.Sprintf("Failed to create request: %v", err)}
 	}
 
-	resp, err := c.HTTPClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return CheckResult{OK: false, Message: fmt.Sprintf("API unreachable: %v", err)}
 	}
@@ -79,8 +107,8 @@ func (c *Client) CheckConnectivity() CheckResult {
 	return CheckResult{OK: true, Message: "IONOS API is reachable"}
 }
 
-func (c *Client) CheckAuthentication() CheckResult {
-	req, err := http.NewRequest("GET", c.BaseURL+"/datacenters?depth=0&limit=1", nil)
+func (c *Client) CheckAuthentication(ctx context.Context) CheckResult {
+	req, err := http.NewRequestWithContext(ctx, "GET", c.BaseURL+"/datacenters?depth=0&limit=1", nil)
 	if err != nil {
 		return CheckResult{OK: false, Message: fmt.Sprintf("Failed to create request: %v", err)}
 	}
@@ -92,7 +120,7 @@ func (c *Client) CheckAuthentication() CheckResult {
 		req.Header.Set("Authorization", "Basic "+auth)
 	}
 
-	resp, err := c.HTTPClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return CheckResult{OK: false, Message: fmt.Sprintf("Request failed: %v", err)}
 	}
@@ -121,39 +149,10 @@ type DataCenter struct {
 	} `json:"properties"`
 }
 
-type DataCentersResponse struct {
-	Items []DataCenter `json:"items"`
-}
-
-func (c *Client) ListDatacenters() ([]DataCenter, error) {
-	req, err := http.NewRequest("GET", c.BaseURL+"/datacenters?depth=1", nil)
-	if err != nil {
-		return nil, err
-	}
-
-	if c.Token != "" {
-		req.Header.Set("Authorization", "Bearer "+c.Token)
-	} else {
-		auth := base64.StdEncoding.EncodeToString([]byte(c.Username + ":" + c.Password))
-		req.Header.Set("Authorization", "Basic "+auth)
-	}
-
-	resp, err := c.HTTPClient.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("API returned status %d", resp.StatusCode)
-	}
-
-	var result DataCentersResponse
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, err
-	}
+type DataCentersResponse = Page[DataCenter]
 
-	return result.Items, nil
+func (c *Client) ListDatacenters(ctx context.Context) ([]DataCenter, error) {
+	return listAll[DataCenter](ctx, c, c.BaseURL+"/datacenters?depth=1")
 }
 
 type Server struct {
@@ -169,9 +168,7 @@ type Server struct {
 	} `json:"metadata"`
 }
 
-type ServersResponse struct {
-	Items []Server `json:"items"`
-}
+type ServersResponse = Page[Server]
 
 type Volume struct {
 	ID         string `json:"id"`
@@ -185,15 +182,34 @@ type Volume struct {
 	} `json:"metadata"`
 }
 
-type VolumesResponse struct {
-	Items []Volume `json:"items"`
-}
+type VolumesResponse = Page[Volume]
 
 type DatacenterStatus struct {
 	Datacenter DataCenter
 	Servers    []Server
 	Volumes    []Volume
-	Issues     []string
+	NICs       []NIC
+	LANs       []LAN
+	Issues     []ResourceIssue
+}
+
+// ResourceIssue is a problem with a server, volume, NIC, LAN or node pool, or
+// with the datacenter or cluster itself (Kind and ID are empty then). Reason
+// tells apart the issues of the datacenter or cluster itself.
+type ResourceIssue struct {
+	Kind    string
+	ID      string
+	Name    string
+	Reason  string
+	Message string
+}
+
+func (i ResourceIssue) String() string {
+	return i.Message
+}
+
+func stateIssue(kind, id, name, state string) ResourceIssue {
+	return ResourceIssue{Kind: kind, ID: id, Name: name, Message: fmt.Sprintf("%s %s state: %s", kind, name, state)}
 }
 
 type K8sCluster struct {
@@ -207,9 +223,7 @@ type K8sCluster struct {
 	} `json:"metadata"`
 }
 
-type K8sClustersResponse struct {
-	Items []K8sCluster `json:"items"`
-}
+type K8sClustersResponse = Page[K8sCluster]
 
 type K8sNodePool struct {
 	ID         string `json:"id"`
@@ -224,101 +238,60 @@ type K8sNodePool struct {
 	} `json:"metadata"`
 }
 
-type K8sNodePoolsResponse struct {
-	Items []K8sNodePool `json:"items"`
-}
+type K8sNodePoolsResponse = Page[K8sNodePool]
 
 type K8sClusterStatus struct {
 	Cluster   K8sCluster
 	NodePools []K8sNodePool
-	Issues    []string
+	Issues    []ResourceIssue
 }
 
-func (c *Client) ListK8sClusters() ([]K8sCluster, error) {
-	req, err := http.NewRequest("GET", c.BaseURL+"/k8s?depth=1", nil)
-	if err != nil {
-		return nil, err
-	}
-
-	c.setAuth(req)
-
-	resp, err := c.HTTPClient.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("API returned status %d", resp.StatusCode)
-	}
-
-	var result K8sClustersResponse
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, err
-	}
+func (c *Client) ListK8sClusters(ctx context.Context) ([]K8sCluster, error) {
+	return listAll[K8sCluster](ctx, c, c.BaseURL+"/k8s?depth=1")
+}
 
-	return result.Items, nil
+func (c *Client) GetK8sNodePools(ctx context.Context, clusterID string) ([]K8sNodePool, error) {
+	return listAll[K8sNodePool](ctx, c, c.BaseURL+"/k8s/"+clusterID+"/nodepools?depth=1")
 }
 
-func (c *Client) GetK8sNodePools(clusterID string) ([]K8sNodePool, error) {
-	req, err := http.NewRequest("GET", c.BaseURL+"/k8s/"+clusterID+"/nodepools?depth=1", nil)
+func (c *Client) CheckK8sClusters(ctx context.Context) ([]K8sClusterStatus, error) {
+	clusters, err := c.ListK8sClusters(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	c.setAuth(req)
-
-	resp, err := c.HTTPClient.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer func() { _ = resp.Body.Close() }()
+	return mapOrdered(clusters, c.concurrency(), func(cluster K8sCluster) K8sClusterStatus {
+		return c.k8sClusterStatus(ctx, cluster)
+	}), nil
+}
 
-	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("API returned status %d", resp.StatusCode)
+func (c *Client) k8sClusterStatus(ctx context.Context, cluster K8sCluster) K8sClusterStatus {
+	status := K8sClusterStatus{
+		Cluster: cluster,
 	}
 
-	var result K8sNodePoolsResponse
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, err
+	if cluster.Metadata.State != "ACTIVE" {
+		status.Issues = append(status.Issues, ResourceIssue{Reason: "State", Message: fmt.Sprintf("Cluster state: %s", cluster.Metadata.State)})
 	}
 
-	return result.Items, nil
-}
-
-func (c *Client) CheckK8sClusters() ([]K8sClusterStatus, error) {
-	clusters, err := c.ListK8sClusters()
+	nodePools, err := c.GetK8sNodePools(ctx, cluster.ID)
 	if err != nil {
-		return nil, err
-	}
-
-	var statuses []K8sClusterStatus
-
-	for _, cluster := range clusters {
-		status := K8sClusterStatus{
-			Cluster: cluster,
-		}
-
-		if cluster.Metadata.State != "ACTIVE" {
-			status.Issues = append(status.Issues, fmt.Sprintf("Cluster state: %s", cluster.Metadata.State))
-		}
-
-		nodePools, err := c.GetK8sNodePools(cluster.ID)
-		if err != nil {
-			status.Issues = append(status.Issues, fmt.Sprintf("Failed to get node pools: %v", err))
-		} else {
-			status.NodePools = nodePools
-			for _, np := range nodePools {
-				if np.Metadata.State != "ACTIVE" {
-					status.Issues = append(status.Issues, fmt.Sprintf("Node pool %s state: %s", np.Properties.Name, np.Metadata.State))
-				}
+		status.Issues = append(status.Issues, ResourceIssue{Reason: "NodePoolsUnavailable", Message: fmt.Sprintf("Failed to get node pools: %v", err)})
+	} else {
+		status.NodePools = nodePools
+		for _, np := range nodePools {
+			if np.Metadata.State != "ACTIVE" {
+				status.Issues = append(status.Issues, ResourceIssue{
+					Kind:    "NodePool",
+					ID:      np.ID,
+					Name:    np.Properties.Name,
+					Message: fmt.Sprintf("Node pool %s state: %s", np.Properties.Name, np.Metadata.State),
+				})
 			}
 		}
-
-		statuses = append(statuses, status)
 	}
 
-	return statuses, nil
+	return status
 }
 
 func (c *Client) setAuth(req *http.Request) {
@@ -330,97 +303,72 @@ func (c *Client) setAuth(req *http.Request) {
 	}
 }
 
-func (c *Client) GetServers(datacenterID string) ([]Server, error) {
-	req, err := http.NewRequest("GET", c.BaseURL+"/datacenters/"+datacenterID+"/servers?depth=1", nil)
-	if err != nil {
-		return nil, err
-	}
-
-	c.setAuth(req)
+func (c *Client) GetServers(ctx context.Context, datacenterID string) ([]Server, error) {
+	return listAll[Server](ctx, c, c.BaseURL+"/datacenters/"+datacenterID+"/servers?depth=1")
+}
 
-	resp, err := c.HTTPClient.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer func() { _ = resp.Body.Close() }()
+func (c *Client) GetVolumes(ctx context.Context, datacenterID string) ([]Volume, error) {
+	return listAll[Volume](ctx, c, c.BaseURL+"/datacenters/"+datacenterID+"/volumes?depth=1")
+}
 
-	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("API returned status %d", resp.StatusCode)
+func (c *Client) CheckDatacenters(ctx context.Context) ([]DatacenterStatus, error) {
+	if c.InventoryMode == InventoryDepth {
+		statuses, err := c.checkDatacenterInventory(ctx)
+		if !errors.Is(err, errPayloadTooLarge) {
+			return statuses, err
+		}
 	}
 
-	var result ServersResponse
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+	datacenters, err := c.ListDatacenters(ctx)
+	if err != nil {
 		return nil, err
 	}
 
-	return result.Items, nil
+	return mapOrdered(datacenters, c.concurrency(), func(dc DataCenter) DatacenterStatus {
+		return c.datacenterStatus(ctx, dc)
+	}), nil
 }
 
-func (c *Client) GetVolumes(datacenterID string) ([]Volume, error) {
-	req, err := http.NewRequest("GET", c.BaseURL+"/datacenters/"+datacenterID+"/volumes?depth=1", nil)
-	if err != nil {
-		return nil, err
+func (c *Client) datacenterStatus(ctx context.Context, dc DataCenter) DatacenterStatus {
+	status := DatacenterStatus{
+		Datacenter: dc,
 	}
 
-	c.setAuth(req)
-
-	resp, err := c.HTTPClient.Do(req)
+	servers, err := c.GetServers(ctx, dc.ID)
 	if err != nil {
-		return nil, err
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("API returned status %d", resp.StatusCode)
-	}
-
-	var result VolumesResponse
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, err
+		status.Issues = append(status.Issues, ResourceIssue{Reason: "ServersUnavailable", Message: fmt.Sprintf("Failed to get servers: %v", err)})
+	} else {
+		status.Servers = servers
+		status.Issues = append(status.Issues, serverIssues(servers)...)
 	}
 
-	return result.Items, nil
-}
-
-func (c *Client) CheckDatacenters() ([]DatacenterStatus, error) {
-	datacenters, err := c.ListDatacenters()
+	volumes, err := c.GetVolumes(ctx, dc.ID)
 	if err != nil {
-		return nil, err
+		status.Issues = append(status.Issues, ResourceIssue{Reason: "VolumesUnavailable", Message: fmt.Sprintf("Failed to get volumes: %v", err)})
+	} else {
+		status.Volumes = volumes
+		status.Issues = append(status.Issues, volumeIssues(volumes)...)
 	}
 
-	var statuses []DatacenterStatus
+	return status
+}
 
-	for _, dc := range datacenters {
-		status := DatacenterStatus{
-			Datacenter: dc,
-		}
-
-		servers, err := c.GetServers(dc.ID)
-		if err != nil {
-			status.Issues = append(status.Issues, fmt.Sprintf("Failed to get servers: %v", err))
-		} else {
-			status.Servers = servers
-			for _, srv := range servers {
-				if srv.Metadata.State == "BUSY" || srv.Metadata.State == "ERROR" {
-					status.Issues = append(status.Issues, fmt.Sprintf("Server %s state: %s", srv.Properties.Name, srv.Metadata.State))
-				}
-			}
+func serverIssues(servers []Server) []ResourceIssue {
+	var issues []ResourceIssue
+	for _, srv := range servers {
+		if srv.Metadata.State == "BUSY" || srv.Metadata.State == "ERROR" {
+			issues = append(issues, stateIssue("Server", srv.ID, srv.Properties.Name, srv.Metadata.State))
 		}
+	}
+	return issues
+}
 
-		volumes, err := c.GetVolumes(dc.ID)
-		if err != nil {
-			status.Issues = append(status.Issues, fmt.Sprintf("Failed to get volumes: %v", err))
-		} else {
-			status.Volumes = volumes
-			for _, vol := range volumes {
-				if vol.Metadata.State != "AVAILABLE" {
-					status.Issues = append(status.Issues, fmt.Sprintf("Volume %s state: %s", vol.Properties.Name, vol.Metadata.State))
-				}
-			}
+func volumeIssues(volumes []Volume) []ResourceIssue {
+	var issues []ResourceIssue
+	for _, vol := range volumes {
+		if vol.Metadata.State != "AVAILABLE" {
+			issues = append(issues, stateIssue("Volume", vol.ID, vol.Properties.Name, vol.Metadata.State))
 		}
-
-		statuses = append(statuses, status)
 	}
-
-	return statuses, nil
+	return issues
 }
@@ -1,6 +1,7 @@
 package ionos
 
 import (
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"net/http"
@@ -60,6 +61,33 @@ func TestNewClientFromEnv_MissingCredentials(t *testing.T) {
 	}
 }
 
+func TestNewClientFromEnv_Concurrency(t *testing.T) {
+	setEnv(t, "IONOS_TOKEN", "token-value")
+	setEnv(t, "IONOS_CONCURRENCY", "")
+
+	client, err := NewClientFromEnv()
+	if err != nil {
+		t.Fatalf("expected client, got error: %v", err)
+	}
+	if client.Concurrency != DefaultConcurrency {
+		t.Fatalf("expected default concurrency %d, got %d", DefaultConcurrency, client.Concurrency)
+	}
+
+	setEnv(t, "IONOS_CONCURRENCY", "3")
+	client, err = NewClientFromEnv()
+	if err != nil {
+		t.Fatalf("expected client, got error: %v", err)
+	}
+	if client.Concurrency != 3 {
+		t.Fatalf("expected concurrency 3, got %d", client.Concurrency)
+	}
+
+	setEnv(t, "IONOS_CONCURRENCY", "0")
+	if _, err := NewClientFromEnv(); err == nil {
+		t.Fatalf("expected error for invalid concurrency")
+	}
+}
+
 func TestCheckAuthentication_WithToken(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if got := r.Header.Get("Authorization"); got != "Bearer test-token" {
@@ -75,7 +103,7 @@ func TestCheckAuthentication_WithToken(t *testing.T) {
 		HTTPClient: server.Client(),
 	}
 
-	result := client.CheckAuthentication()
+	result := client.CheckAuthentication(context.Background())
 
 	if !result.OK {
 		t.Fatalf("expected authentication to succeed, got: %s", result.Message)
@@ -99,7 +127,7 @@ func TestCheckAuthentication_WithBasicAuth(t *testing.T) {
 		HTTPClient: server.Client(),
 	}
 
-	result := client.CheckAuthentication()
+	result := client.CheckAuthentication(context.Background())
 
 	if result.OK {
 		t.Fatalf("expected authentication to fail")
@@ -121,7 +149,7 @@ func TestCheckAuthentication_Forbidden(t *testing.T) {
 		HTTPClient: server.Client(),
 	}
 
-	result := client.CheckAuthentication()
+	result := client.CheckAuthentication(context.Background())
 
 	if result.OK {
 		t.Fatalf("expected authentication to fail with forbidden")
@@ -218,7 +246,7 @@ func TestCheckDatacenters_CollectsIssues(t *testing.T) {
 		HTTPClient: server.Client(),
 	}
 
-	statuses, err := client.CheckDatacenters()
+	statuses, err := client.CheckDatacenters(context.Background())
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -231,8 +259,13 @@ func TestCheckDatacenters_CollectsIssues(t *testing.T) {
 	if len(issues) != 2 {
 		t.Fatalf("expected 2 issues, got %d: %v", len(issues), issues)
 	}
-	assertContains(t, issues, "Server web-2 state: BUSY")
-	assertContains(t, issues, "Volume vol2 state: BUSY")
+	assertContains(t, issueMessages(issues), "Server web-2 state: BUSY")
+	assertContains(t, issueMessages(issues), "Volume vol2 state: BUSY")
+	for _, issue := range issues {
+		if issue.Kind == "" || issue.ID == "" || issue.Name == "" {
+			t.Fatalf("expected the issue to identify its resource, got %+v", issue)
+		}
+	}
 }
 
 func setEnv(t *testing.T, key, value string) {
@@ -261,6 +294,14 @@ func requireAuthHeader(t *testing.T, r *http.Request) {
 	}
 }
 
+func issueMessages(issues []ResourceIssue) []string {
+	messages := make([]string, 0, len(issues))
+	for _, issue := range issues {
+		messages = append(messages, issue.Message)
+	}
+	return messages
+}
+
 func assertContains(t *testing.T, list []string, expected string) {
 	t.Helper()
 	for _, item := range list {
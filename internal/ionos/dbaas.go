@@ -1,9 +1,9 @@
 package ionos
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
-	"net/http"
+	"sync"
 )
 
 const (
@@ -26,9 +26,7 @@ type PostgreSQLCluster struct {
 	} `json:"metadata"`
 }
 
-type PostgreSQLClustersResponse struct {
-	Items []PostgreSQLCluster `json:"items"`
-}
+type PostgreSQLClustersResponse = Page[PostgreSQLCluster]
 
 type MongoDBCluster struct {
 	ID         string `json:"id"`
@@ -44,9 +42,7 @@ type MongoDBCluster struct {
 	} `json:"metadata"`
 }
 
-type MongoDBClustersResponse struct {
-	Items []MongoDBCluster `json:"items"`
-}
+type MongoDBClustersResponse = Page[MongoDBCluster]
 
 type MariaDBCluster struct {
 	ID         string `json:"id"`
@@ -61,9 +57,7 @@ type MariaDBCluster struct {
 	} `json:"metadata"`
 }
 
-type MariaDBClustersResponse struct {
-	Items []MariaDBCluster `json:"items"`
-}
+type MariaDBClustersResponse = Page[MariaDBCluster]
 
 type InMemoryDBInstance struct {
 	ID         string `json:"id"`
@@ -78,135 +72,152 @@ type InMemoryDBInstance struct {
 	} `json:"metadata"`
 }
 
-type InMemoryDBInstancesResponse struct {
-	Items []InMemoryDBInstance `json:"items"`
-}
+type InMemoryDBInstancesResponse = Page[InMemoryDBInstance]
+
+const (
+	EnginePostgreSQL = "postgresql"
+	EngineMongoDB    = "mongodb"
+	EngineMariaDB    = "mariadb"
+	EngineInMemoryDB = "inmemorydb"
+)
 
 type DBaaSStatus struct {
 	PostgreSQL []PostgreSQLCluster
 	MongoDB    []MongoDBCluster
 	MariaDB    []MariaDBCluster
 	InMemoryDB []InMemoryDBInstance
-	Issues     []string
+	Issues     []DBaaSIssue
 }
 
-func (c *Client) makeDBaaSRequest(url string, result interface{}) error {
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return err
-	}
+// DBaaSIssue is a problem with a single cluster, or with a whole engine when
+// its clusters could not be listed (ClusterID is empty then).
+type DBaaSIssue struct {
+	Engine    string
+	ClusterID string
+	Cluster   string
+	Message   string
+}
 
-	c.setAuth(req)
+func (i DBaaSIssue) String() string {
+	return i.Message
+}
 
-	resp, err := c.HTTPClient.Do(req)
-	if err != nil {
-		return err
-	}
-	defer func() { _ = resp.Body.Close() }()
+// DBaaSStateOK reports whether a DBaaS cluster state is healthy.
+func DBaaSStateOK(state string) bool {
+	return state == "AVAILABLE" || state == "ACTIVE"
+}
 
-	if resp.StatusCode == 404 {
-		return nil
-	}
+func (s *DBaaSStatus) addListError(engine, what string, err error) {
+	s.Issues = append(s.Issues, DBaaSIssue{Engine: engine, Message: fmt.Sprintf("Failed to get %s: %v", what, err)})
+}
 
-	if resp.StatusCode != 200 {
-		return fmt.Errorf("API returned status %d", resp.StatusCode)
+func (s *DBaaSStatus) checkState(engine, kind, id, name, state string) {
+	if DBaaSStateOK(state) {
+		return
 	}
+	s.Issues = append(s.Issues, DBaaSIssue{
+		Engine:    engine,
+		ClusterID: id,
+		Cluster:   name,
+		Message:   fmt.Sprintf("%s %s state: %s", kind, name, state),
+	})
+}
 
-	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
-		return err
+// listDBaaS treats a 404 as an empty list, since DBaaS APIs return it for
+// contracts without access to the product.
+func listDBaaS[T any](ctx context.Context, c *Client, url string) ([]T, error) {
+	items, err := listAll[T](ctx, c, url)
+	if isNotFound(err) {
+		return nil, nil
 	}
-
-	return nil
+	return items, err
 }
 
-func (c *Client) ListPostgreSQLClusters() ([]PostgreSQLCluster, error) {
-	var response PostgreSQLClustersResponse
-	err := c.makeDBaaSRequest(c.PostgreSQLBaseURL+"/clusters", &response)
-	if err != nil {
-		return nil, err
-	}
-	return response.Items, nil
+func (c *Client) ListPostgreSQLClusters(ctx context.Context) ([]PostgreSQLCluster, error) {
+	return listDBaaS[PostgreSQLCluster](ctx, c, c.PostgreSQLBaseURL+"/clusters")
 }
 
-func (c *Client) ListMongoDBClusters() ([]MongoDBCluster, error) {
-	var response MongoDBClustersResponse
-	err := c.makeDBaaSRequest(c.MongoDBBaseURL+"/clusters", &response)
-	if err != nil {
-		return nil, err
-	}
-	return response.Items, nil
+func (c *Client) ListMongoDBClusters(ctx context.Context) ([]MongoDBCluster, error) {
+	return listDBaaS[MongoDBCluster](ctx, c, c.MongoDBBaseURL+"/clusters")
 }
 
-func (c *Client) ListMariaDBClusters() ([]MariaDBCluster, error) {
-	var response MariaDBClustersResponse
-	err := c.makeDBaaSRequest(c.MariaDBBaseURL+"/clusters", &response)
-	if err != nil {
-		return nil, err
-	}
-	return response.Items, nil
+func (c *Client) ListMariaDBClusters(ctx context.Context) ([]MariaDBCluster, error) {
+	return listDBaaS[MariaDBCluster](ctx, c, c.MariaDBBaseURL+"/clusters")
 }
 
-func (c *Client) ListInMemoryDBInstances() ([]InMemoryDBInstance, error) {
-	var response InMemoryDBInstancesResponse
-	err := c.makeDBaaSRequest(c.InMemoryDBBaseURL+"/instances", &response)
-	if err != nil {
-		return nil, err
-	}
-	return response.Items, nil
+func (c *Client) ListInMemoryDBInstances(ctx context.Context) ([]InMemoryDBInstance, error) {
+	return listDBaaS[InMemoryDBInstance](ctx, c, c.InMemoryDBBaseURL+"/instances")
 }
 
-func (c *Client) CheckDBaaS() DBaaSStatus {
+func (c *Client) CheckDBaaS(ctx context.Context) DBaaSStatus {
 	status := DBaaSStatus{}
 
-	pgClusters, err := c.ListPostgreSQLClusters()
-	if err != nil {
-		status.Issues = append(status.Issues, fmt.Sprintf("Failed to get PostgreSQL clusters: %v", err))
+	var (
+		wg                sync.WaitGroup
+		pgClusters        []PostgreSQLCluster
+		mongoClusters     []MongoDBCluster
+		mariadbClusters   []MariaDBCluster
+		inMemoryInstances []InMemoryDBInstance
+		pgErr             error
+		mongoErr          error
+		mariadbErr        error
+		inMemoryErr       error
+	)
+
+	// The engines are independent APIs; fetch them concurrently and evaluate
+	// the results in a fixed order afterwards.
+	wg.Add(4)
+	go func() {
+		defer wg.Done()
+		pgClusters, pgErr = c.ListPostgreSQLClusters(ctx)
+	}()
+	go func() {
+		defer wg.Done()
+		mongoClusters, mongoErr = c.ListMongoDBClusters(ctx)
+	}()
+	go func() {
+		defer wg.Done()
+		mariadbClusters, mariadbErr = c.ListMariaDBClusters(ctx)
+	}()
+	go func() {
+		defer wg.Done()
+		inMemoryInstances, inMemoryErr = c.ListInMemoryDBInstances(ctx)
+	}()
+	wg.Wait()
+
+	if pgErr != nil {
+		status.addListError(EnginePostgreSQL, "PostgreSQL clusters", pgErr)
 	} else {
 		status.PostgreSQL = pgClusters
 		for _, cluster := range pgClusters {
-			if cluster.Metadata.State != "AVAILABLE" && cluster.Metadata.State != "ACTIVE" {
-				status.Issues = append(status.Issues,
-					fmt.Sprintf("PostgreSQL cluster %s state: %s", cluster.Properties.DisplayName, cluster.Metadata.State))
-			}
+			status.checkState(EnginePostgreSQL, "PostgreSQL cluster", cluster.ID, cluster.Properties.DisplayName, cluster.Metadata.State)
 		}
 	}
 
-	mongoClusters, err := c.ListMongoDBClusters()
-	if err != nil {
-		status.Issues = append(status.Issues, fmt.Sprintf("Failed to get MongoDB clusters: %v", err))
+	if mongoErr != nil {
+		status.addListError(EngineMongoDB, "MongoDB clusters", mongoErr)
 	} else {
 		status.MongoDB = mongoClusters
 		for _, cluster := range mongoClusters {
-			if cluster.Metadata.State != "AVAILABLE" && cluster.Metadata.State != "ACTIVE" {
-				status.Issues = append(status.Issues,
-					fmt.Sprintf("MongoDB cluster %s state: %s", cluster.Properties.DisplayName, cluster.Metadata.State))
-			}
+			status.checkState(EngineMongoDB, "MongoDB cluster", cluster.ID, cluster.Properties.DisplayName, cluster.Metadata.State)
 		}
 	}
 
-	mariadbClusters, err := c.ListMariaDBClusters()
-	if err != nil {
-		status.Issues = append(status.Issues, fmt.Sprintf("Failed to get MariaDB clusters: %v", err))
+	if mariadbErr != nil {
+		status.addListError(EngineMariaDB, "MariaDB clusters", mariadbErr)
 	} else {
 		status.MariaDB = mariadbClusters
 		for _, cluster := range mariadbClusters {
-			if cluster.Metadata.State != "AVAILABLE" && cluster.Metadata.State != "ACTIVE" {
-				status.Issues = append(status.Issues,
-					fmt.Sprintf("MariaDB cluster %s state: %s", cluster.Properties.DisplayName, cluster.Metadata.State))
-			}
+			status.checkState(EngineMariaDB, "MariaDB cluster", cluster.ID, cluster.Properties.DisplayName, cluster.Metadata.State)
 		}
 	}
 
-	inMemoryInstances, err := c.ListInMemoryDBInstances()
-	if err != nil {
-		status.Issues = append(status.Issues, fmt.Sprintf("Failed to get In-Memory DB instances: %v", err))
+	if inMemoryErr != nil {
+		status.addListError(EngineInMemoryDB, "In-Memory DB instances", inMemoryErr)
 	} else {
 		status.InMemoryDB = inMemoryInstances
 		for _, instance := range inMemoryInstances {
-			if instance.Metadata.State != "AVAILABLE" && instance.Metadata.State != "ACTIVE" {
-				status.Issues = append(status.Issues,
-					fmt.Sprintf("In-Memory DB instance %s state: %s", instance.Properties.DisplayName, instance.Metadata.State))
-			}
+			status.checkState(EngineInMemoryDB, "In-Memory DB instance", instance.ID, instance.Properties.DisplayName, instance.Metadata.State)
 		}
 	}
 
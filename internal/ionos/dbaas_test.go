@@ -1,6 +1,7 @@
 package ionos
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
@@ -52,7 +53,7 @@ func TestListPostgreSQLClusters_Success(t *testing.T) {
 
 	client := newTestClient(server.URL)
 
-	clusters, err := client.ListPostgreSQLClusters()
+	clusters, err := client.ListPostgreSQLClusters(context.Background())
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -105,7 +106,7 @@ func TestListMongoDBClusters_Success(t *testing.T) {
 
 	client := newTestClient(server.URL)
 
-	clusters, err := client.ListMongoDBClusters()
+	clusters, err := client.ListMongoDBClusters(context.Background())
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -153,7 +154,7 @@ func TestListMariaDBClusters_Success(t *testing.T) {
 
 	client := newTestClient(server.URL)
 
-	clusters, err := client.ListMariaDBClusters()
+	clusters, err := client.ListMariaDBClusters(context.Background())
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -198,7 +199,7 @@ func TestListInMemoryDBInstances_Success(t *testing.T) {
 
 	client := newTestClient(server.URL)
 
-	instances, err := client.ListInMemoryDBInstances()
+	instances, err := client.ListInMemoryDBInstances(context.Background())
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -225,7 +226,7 @@ func TestDBaaS_404ReturnsEmpty(t *testing.T) {
 
 	client := newTestClient(server.URL)
 
-	clusters, err := client.ListPostgreSQLClusters()
+	clusters, err := client.ListPostgreSQLClusters(context.Background())
 	if err != nil {
 		t.Fatalf("expected no error for 404, got: %v", err)
 	}
@@ -243,75 +244,71 @@ func TestDBaaS_ErrorHandling(t *testing.T) {
 
 	client := newTestClient(server.URL)
 
-	_, err := client.ListPostgreSQLClusters()
+	_, err := client.ListPostgreSQLClusters(context.Background())
 	if err == nil {
 		t.Fatalf("expected error for 500 status")
 	}
 }
 
 func TestCheckDBaaS_CollectsIssues(t *testing.T) {
-	callCount := 0
+	// The engines are listed concurrently, so route by engine prefix rather than call order.
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		requireAuthHeader(t, r)
-		callCount++
 
 		switch r.URL.Path {
-		case "/clusters":
-			switch callCount {
-			case 1:
-				resp := PostgreSQLClustersResponse{
-					Items: []PostgreSQLCluster{
-						{
-							ID: "pg-ok",
-							Properties: struct {
-								DisplayName     string `json:"displayName"`
-								PostgresVersion string `json:"postgresVersion"`
-								Location        string `json:"location"`
-								Instances       int    `json:"instances"`
-							}{DisplayName: "pg-healthy"},
-							Metadata: struct {
-								State string `json:"state"`
-							}{State: "AVAILABLE"},
-						},
-						{
-							ID: "pg-bad",
-							Properties: struct {
-								DisplayName     string `json:"displayName"`
-								PostgresVersion string `json:"postgresVersion"`
-								Location        string `json:"location"`
-								Instances       int    `json:"instances"`
-							}{DisplayName: "pg-unhealthy"},
-							Metadata: struct {
-								State string `json:"state"`
-							}{State: "BUSY"},
-						},
+		case "/postgresql/clusters":
+			resp := PostgreSQLClustersResponse{
+				Items: []PostgreSQLCluster{
+					{
+						ID: "pg-ok",
+						Properties: struct {
+							DisplayName     string `json:"displayName"`
+							PostgresVersion string `json:"postgresVersion"`
+							Location        string `json:"location"`
+							Instances       int    `json:"instances"`
+						}{DisplayName: "pg-healthy"},
+						Metadata: struct {
+							State string `json:"state"`
+						}{State: "AVAILABLE"},
+					},
+					{
+						ID: "pg-bad",
+						Properties: struct {
+							DisplayName     string `json:"displayName"`
+							PostgresVersion string `json:"postgresVersion"`
+							Location        string `json:"location"`
+							Instances       int    `json:"instances"`
+						}{DisplayName: "pg-unhealthy"},
+						Metadata: struct {
+							State string `json:"state"`
+						}{State: "BUSY"},
 					},
-				}
-				_ = json.NewEncoder(w).Encode(resp)
-			case 2:
-				resp := MongoDBClustersResponse{
-					Items: []MongoDBCluster{
-						{
-							ID: "mongo-bad",
-							Properties: struct {
-								DisplayName    string `json:"displayName"`
-								MongoDBVersion string `json:"mongoDBVersion"`
-								Location       string `json:"location"`
-								Instances      int    `json:"instances"`
-								Edition        string `json:"edition"`
-							}{DisplayName: "mongo-unhealthy"},
-							Metadata: struct {
-								State string `json:"state"`
-							}{State: "UPDATING"},
-						},
+				},
+			}
+			_ = json.NewEncoder(w).Encode(resp)
+		case "/mongodb/clusters":
+			resp := MongoDBClustersResponse{
+				Items: []MongoDBCluster{
+					{
+						ID: "mongo-bad",
+						Properties: struct {
+							DisplayName    string `json:"displayName"`
+							MongoDBVersion string `json:"mongoDBVersion"`
+							Location       string `json:"location"`
+							Instances      int    `json:"instances"`
+							Edition        string `json:"edition"`
+						}{DisplayName: "mongo-unhealthy"},
+						Metadata: struct {
+							State string `json:"state"`
+						}{State: "UPDATING"},
 					},
-				}
-				_ = json.NewEncoder(w).Encode(resp)
-			case 3:
-				resp := MariaDBClustersResponse{Items: []MariaDBCluster{}}
-				_ = json.NewEncoder(w).Encode(resp)
+				},
 			}
-		case "/instances":
+			_ = json.NewEncoder(w).Encode(resp)
+		case "/mariadb/clusters":
+			resp := MariaDBClustersResponse{Items: []MariaDBCluster{}}
+			_ = json.NewEncoder(w).Encode(resp)
+		case "/in-memory-db/instances":
 			resp := InMemoryDBInstancesResponse{Items: []InMemoryDBInstance{}}
 			_ = json.NewEncoder(w).Encode(resp)
 		default:
@@ -321,8 +318,12 @@ func TestCheckDBaaS_CollectsIssues(t *testing.T) {
 	defer server.Close()
 
 	client := newTestClient(server.URL)
+	client.PostgreSQLBaseURL = server.URL + "/postgresql"
+	client.MongoDBBaseURL = server.URL + "/mongodb"
+	client.MariaDBBaseURL = server.URL + "/mariadb"
+	client.InMemoryDBBaseURL = server.URL + "/in-memory-db"
 
-	status := client.CheckDBaaS()
+	status := client.CheckDBaaS(context.Background())
 
 	if len(status.PostgreSQL) != 2 {
 		t.Fatalf("expected 2 PostgreSQL clusters, got %d", len(status.PostgreSQL))
@@ -341,8 +342,15 @@ func TestCheckDBaaS_CollectsIssues(t *testing.T) {
 		t.Fatalf("expected 2 issues, got %d: %v", len(status.Issues), status.Issues)
 	}
 
-	assertContains(t, status.Issues, "PostgreSQL cluster pg-unhealthy state: BUSY")
-	assertContains(t, status.Issues, "MongoDB cluster mongo-unhealthy state: UPDATING")
+	var messages []string
+	for _, issue := range status.Issues {
+		messages = append(messages, issue.Message)
+	}
+	assertContains(t, messages, "PostgreSQL cluster pg-unhealthy state: BUSY")
+	assertContains(t, messages, "MongoDB cluster mongo-unhealthy state: UPDATING")
+	if status.Issues[0].Engine != EnginePostgreSQL || status.Issues[0].Cluster != "pg-unhealthy" || status.Issues[0].ClusterID == "" {
+		t.Fatalf("expected the issue to identify the cluster, got %+v", status.Issues[0])
+	}
 }
 
 func TestCheckDBaaS_NoIssuesWhenAllHealthy(t *testing.T) {
@@ -378,7 +386,7 @@ func TestCheckDBaaS_NoIssuesWhenAllHealthy(t *testing.T) {
 
 	client := newTestClient(server.URL)
 
-	status := client.CheckDBaaS()
+	status := client.CheckDBaaS(context.Background())
 
 	if len(status.Issues) != 0 {
 		t.Fatalf("expected no issues, got %d: %v", len(status.Issues), status.Issues)
@@ -418,7 +426,7 @@ func TestCheckDBaaS_ActiveStateIsHealthy(t *testing.T) {
 
 	client := newTestClient(server.URL)
 
-	status := client.CheckDBaaS()
+	status := client.CheckDBaaS(context.Background())
 
 	if len(status.Issues) != 0 {
 		t.Fatalf("expected no issues for ACTIVE state, got: %v", status.Issues)
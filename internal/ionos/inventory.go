@@ -0,0 +1,161 @@
+package ionos
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+)
+
+const (
+	InventoryPerResource = "per-resource"
+	InventoryDepth       = "depth"
+
+	DefaultMaxInventoryBytes = 16 << 20
+
+	// inventoryDepth expands datacenter > entities > servers > server
+	// entities > NICs, so a single listing carries the whole inventory.
+	inventoryDepth = 5
+)
+
+type NIC struct {
+	ID         string `json:"id"`
+	Properties struct {
+		Name string   `json:"name"`
+		MAC  string   `json:"mac"`
+		IPs  []string `json:"ips"`
+		LAN  int      `json:"lan"`
+	} `json:"properties"`
+	Metadata struct {
+		State string `json:"state"`
+	} `json:"metadata"`
+}
+
+type LAN struct {
+	ID         string `json:"id"`
+	Properties struct {
+		Name   string `json:"name"`
+		Public bool   `json:"public"`
+	} `json:"properties"`
+	Metadata struct {
+		State string `json:"state"`
+	} `json:"metadata"`
+}
+
+type datacenterInventory struct {
+	DataCenter
+	Entities struct {
+		Servers *Page[serverInventory] `json:"servers"`
+		Volumes *Page[Volume]          `json:"volumes"`
+		LANs    *Page[LAN]             `json:"lans"`
+	} `json:"entities"`
+}
+
+type serverInventory struct {
+	Server
+	Entities struct {
+		NICs *Page[NIC] `json:"nics"`
+	} `json:"entities"`
+}
+
+func concurrencyFromEnv() (int, error) {
+	value := os.Getenv("IONOS_CONCURRENCY")
+	if value == "" {
+		return DefaultConcurrency, nil
+	}
+	concurrency, err := strconv.Atoi(value)
+	if err != nil || concurrency <= 0 {
+		return 0, fmt.Errorf("invalid IONOS_CONCURRENCY %q: must be a positive integer", value)
+	}
+	return concurrency, nil
+}
+
+func inventoryModeFromEnv() (string, error) {
+	switch mode := os.Getenv("IONOS_INVENTORY_MODE"); mode {
+	case "":
+		return InventoryPerResource, nil
+	case InventoryPerResource, InventoryDepth:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("invalid inventory mode %q (expected %s or %s)", mode, InventoryDepth, InventoryPerResource)
+	}
+}
+
+func ValidateEnv() error {
+	if _, err := concurrencyFromEnv(); err != nil {
+		return err
+	}
+	_, err := inventoryModeFromEnv()
+	return err
+}
+
+func (c *Client) maxInventoryBytes() int64 {
+	if c.MaxInventoryBytes > 0 {
+		return c.MaxInventoryBytes
+	}
+	return DefaultMaxInventoryBytes
+}
+
+// checkDatacenterInventory lists every datacenter with its servers, volumes,
+// NICs and LANs in one expanded request. It returns errPayloadTooLarge when
+// the response exceeds MaxInventoryBytes so the caller can fall back to
+// per-resource requests.
+func (c *Client) checkDatacenterInventory(ctx context.Context) ([]DatacenterStatus, error) {
+	url := fmt.Sprintf("%s/datacenters?depth=%d", c.BaseURL, inventoryDepth)
+	inventories, err := listAllLimited[datacenterInventory](ctx, c, url, c.maxInventoryBytes())
+	if err != nil {
+		return nil, err
+	}
+
+	return mapOrdered(inventories, c.concurrency(), func(inv datacenterInventory) DatacenterStatus {
+		// Datacenters the API did not expand are fetched per resource instead.
+		if inv.Entities.Servers == nil || inv.Entities.Volumes == nil {
+			return c.datacenterStatus(ctx, inv.DataCenter)
+		}
+		return inv.status()
+	}), nil
+}
+
+func (inv datacenterInventory) status() DatacenterStatus {
+	status := DatacenterStatus{
+		Datacenter: inv.DataCenter,
+		Volumes:    inv.Entities.Volumes.Items,
+	}
+
+	for _, srv := range inv.Entities.Servers.Items {
+		status.Servers = append(status.Servers, srv.Server)
+		if srv.Entities.NICs != nil {
+			status.NICs = append(status.NICs, srv.Entities.NICs.Items...)
+		}
+	}
+	if inv.Entities.LANs != nil {
+		status.LANs = inv.Entities.LANs.Items
+	}
+
+	status.Issues = append(status.Issues, serverIssues(status.Servers)...)
+	status.Issues = append(status.Issues, volumeIssues(status.Volumes)...)
+	status.Issues = append(status.Issues, nicIssues(status.NICs)...)
+	status.Issues = append(status.Issues, lanIssues(status.LANs)...)
+
+	return status
+}
+
+func nicIssues(nics []NIC) []ResourceIssue {
+	var issues []ResourceIssue
+	for _, nic := range nics {
+		if nic.Metadata.State != "AVAILABLE" {
+			issues = append(issues, stateIssue("NIC", nic.ID, nic.Properties.Name, nic.Metadata.State))
+		}
+	}
+	return issues
+}
+
+func lanIssues(lans []LAN) []ResourceIssue {
+	var issues []ResourceIssue
+	for _, lan := range lans {
+		if lan.Metadata.State != "AVAILABLE" {
+			issues = append(issues, stateIssue("LAN", lan.ID, lan.Properties.Name, lan.Metadata.State))
+		}
+	}
+	return issues
+}
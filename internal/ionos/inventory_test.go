@@ -0,0 +1,181 @@
+package ionos
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+const inventoryResponse = `{
+  "items": [{
+    "id": "dc1",
+    "properties": {"name": "DC One", "location": "de/fra"},
+    "entities": {
+      "servers": {"items": [{
+        "id": "srv1",
+        "properties": {"name": "web-1"},
+        "metadata": {"state": "BUSY"},
+        "entities": {"nics": {"items": [
+          {"id": "nic1", "properties": {"name": "eth0", "lan": 1}, "metadata": {"state": "AVAILABLE"}},
+          {"id": "nic2", "properties": {"name": "eth1", "lan": 2}, "metadata": {"state": "BUSY"}}
+        ]}}
+      }]},
+      "volumes": {"items": [
+        {"id": "vol1", "properties": {"name": "data"}, "metadata": {"state": "AVAILABLE"}}
+      ]},
+      "lans": {"items": [
+        {"id": "1", "properties": {"name": "public", "public": true}, "metadata": {"state": "AVAILABLE"}}
+      ]}
+    }
+  }]
+}`
+
+func TestCheckDatacenters_DepthModeUsesSingleRequest(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		requireAuthHeader(t, r)
+		if r.URL.Path != "/datacenters" || r.URL.Query().Get("depth") != "5" {
+			t.Fatalf("unexpected request: %s", r.URL)
+		}
+		_, _ = w.Write([]byte(inventoryResponse))
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	client.InventoryMode = InventoryDepth
+
+	statuses, err := client.CheckDatacenters(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if requests.Load() != 1 {
+		t.Fatalf("expected 1 request, got %d", requests.Load())
+	}
+	if len(statuses) != 1 {
+		t.Fatalf("expected 1 datacenter, got %d", len(statuses))
+	}
+
+	status := statuses[0]
+	if status.Datacenter.Properties.Name != "DC One" {
+		t.Fatalf("unexpected datacenter: %+v", status.Datacenter)
+	}
+	if len(status.Servers) != 1 || len(status.Volumes) != 1 || len(status.NICs) != 2 || len(status.LANs) != 1 {
+		t.Fatalf("unexpected inventory: %d servers, %d volumes, %d nics, %d lans",
+			len(status.Servers), len(status.Volumes), len(status.NICs), len(status.LANs))
+	}
+
+	assertContains(t, issueMessages(status.Issues), "Server web-1 state: BUSY")
+	assertContains(t, issueMessages(status.Issues), "NIC eth1 state: BUSY")
+	if len(status.Issues) != 2 {
+		t.Fatalf("expected 2 issues, got %v", status.Issues)
+	}
+}
+
+func TestCheckDatacenters_DepthModeFallsBackWhenPayloadTooLarge(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/datacenters" && r.URL.Query().Get("depth") == "5":
+			_, _ = w.Write([]byte(inventoryResponse))
+		case r.URL.Path == "/datacenters":
+			_, _ = w.Write([]byte(`{"items": [{"id": "dc1", "properties": {"name": "DC One"}}]}`))
+		case strings.HasSuffix(r.URL.Path, "/servers"):
+			_, _ = w.Write([]byte(`{"items": [{"id": "srv1", "properties": {"name": "web-1"}, "metadata": {"state": "AVAILABLE"}}]}`))
+		case strings.HasSuffix(r.URL.Path, "/volumes"):
+			_, _ = w.Write([]byte(`{"items": []}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	client.InventoryMode = InventoryDepth
+	client.MaxInventoryBytes = 64
+
+	statuses, err := client.CheckDatacenters(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(statuses) != 1 || len(statuses[0].Servers) != 1 {
+		t.Fatalf("expected per-resource fallback result, got %+v", statuses)
+	}
+	if statuses[0].NICs != nil {
+		t.Fatalf("expected no NICs from per-resource fallback, got %v", statuses[0].NICs)
+	}
+}
+
+func TestCheckDatacenters_DepthModeFetchesUnexpandedDatacenters(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/datacenters":
+			_, _ = w.Write([]byte(`{"items": [{"id": "dc1", "properties": {"name": "DC One"}}]}`))
+		case "/datacenters/dc1/servers":
+			_, _ = w.Write([]byte(`{"items": []}`))
+		case "/datacenters/dc1/volumes":
+			_, _ = w.Write([]byte(`{"items": [{"id": "vol1", "properties": {"name": "data"}, "metadata": {"state": "BUSY"}}]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	client.InventoryMode = InventoryDepth
+
+	statuses, err := client.CheckDatacenters(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(statuses) != 1 {
+		t.Fatalf("expected 1 datacenter, got %d", len(statuses))
+	}
+	assertContains(t, issueMessages(statuses[0].Issues), "Volume data state: BUSY")
+}
+
+func TestGetJSONLimited_RequestEntityTooLarge(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusRequestEntityTooLarge)
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+
+	var result map[string]any
+	if err := client.getJSONLimited(context.Background(), server.URL, &result, 1024); err != errPayloadTooLarge {
+		t.Fatalf("expected errPayloadTooLarge, got %v", err)
+	}
+}
+
+func TestNewClientFromEnv_InventoryMode(t *testing.T) {
+	setEnv(t, "IONOS_TOKEN", "token-value")
+	setEnv(t, "IONOS_INVENTORY_MODE", "")
+
+	client, err := NewClientFromEnv()
+	if err != nil {
+		t.Fatalf("expected client, got error: %v", err)
+	}
+	if client.InventoryMode != InventoryPerResource {
+		t.Fatalf("expected default inventory mode %q, got %q", InventoryPerResource, client.InventoryMode)
+	}
+
+	setEnv(t, "IONOS_INVENTORY_MODE", "depth")
+	client, err = NewClientFromEnv()
+	if err != nil {
+		t.Fatalf("expected client, got error: %v", err)
+	}
+	if client.InventoryMode != InventoryDepth {
+		t.Fatalf("expected inventory mode %q, got %q", InventoryDepth, client.InventoryMode)
+	}
+
+	setEnv(t, "IONOS_INVENTORY_MODE", "deep")
+	if err := ValidateEnv(); err == nil {
+		t.Fatalf("expected error for unknown inventory mode")
+	}
+}
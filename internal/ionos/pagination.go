@@ -0,0 +1,108 @@
+package ionos
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+const (
+	defaultPageSize = 100
+	maxPages        = 1000
+)
+
+type Links struct {
+	Prev string `json:"prev,omitempty"`
+	Self string `json:"self,omitempty"`
+	Next string `json:"next,omitempty"`
+}
+
+type Page[T any] struct {
+	Items  []T   `json:"items"`
+	Offset int   `json:"offset,omitempty"`
+	Limit  int   `json:"limit,omitempty"`
+	Links  Links `json:"_links,omitempty"`
+}
+
+func (c *Client) pageSize() int {
+	if c.PageSize > 0 {
+		return c.PageSize
+	}
+	return defaultPageSize
+}
+
+// listAll follows _links.next (or offset/limit when no link is returned)
+// until every page of a collection has been fetched.
+func listAll[T any](ctx context.Context, c *Client, rawURL string) ([]T, error) {
+	return listAllLimited[T](ctx, c, rawURL, 0)
+}
+
+func listAllLimited[T any](ctx context.Context, c *Client, rawURL string, maxPageBytes int64) ([]T, error) {
+	pageURL, err := withPagination(rawURL, 0, c.pageSize())
+	if err != nil {
+		return nil, err
+	}
+
+	var items []T
+	for i := 0; pageURL != ""; i++ {
+		if i >= maxPages {
+			return nil, fmt.Errorf("pagination exceeded %d pages", maxPages)
+		}
+
+		var page Page[T]
+		if err := c.getJSONLimited(ctx, pageURL, &page, maxPageBytes); err != nil {
+			return nil, err
+		}
+		items = append(items, page.Items...)
+
+		next, err := nextPageURL(pageURL, page.Links.Next, page.Offset, page.Limit, len(page.Items))
+		if err != nil {
+			return nil, err
+		}
+		if next == pageURL {
+			break
+		}
+		pageURL = next
+	}
+
+	return items, nil
+}
+
+func withPagination(rawURL string, offset, limit int) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", err
+	}
+
+	query := u.Query()
+	query.Set("offset", strconv.Itoa(offset))
+	query.Set("limit", strconv.Itoa(limit))
+	u.RawQuery = query.Encode()
+
+	return u.String(), nil
+}
+
+func nextPageURL(current, next string, offset, limit, count int) (string, error) {
+	if next != "" {
+		base, err := url.Parse(current)
+		if err != nil {
+			return "", err
+		}
+		ref, err := url.Parse(next)
+		if err != nil {
+			return "", fmt.Errorf("invalid pagination link %q: %w", next, err)
+		}
+		resolved := base.ResolveReference(ref)
+		if resolved.Host != base.Host {
+			return "", fmt.Errorf("refusing to follow pagination link to %s", resolved.Host)
+		}
+		return resolved.String(), nil
+	}
+
+	if limit > 0 && count >= limit {
+		return withPagination(current, offset+limit, limit)
+	}
+
+	return "", nil
+}
@@ -0,0 +1,149 @@
+package ionos
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+// pagedHandler serves total items named prefix-N, honouring offset/limit and
+// advertising the next page through _links.next when withLinks is set.
+func pagedHandler(t *testing.T, prefix string, total int, withLinks bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		requireAuthHeader(t, r)
+
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+		if err != nil || limit <= 0 {
+			t.Fatalf("expected limit query parameter, got %q", r.URL.RawQuery)
+		}
+
+		page := Page[map[string]string]{Offset: offset, Limit: limit, Items: []map[string]string{}}
+		for i := offset; i < total && i < offset+limit; i++ {
+			page.Items = append(page.Items, map[string]string{"id": fmt.Sprintf("%s-%d", prefix, i)})
+		}
+		if withLinks && offset+limit < total {
+			page.Links.Next = fmt.Sprintf("%s?depth=1&offset=%d&limit=%d", r.URL.Path, offset+limit, limit)
+		}
+		_ = json.NewEncoder(w).Encode(page)
+	}
+}
+
+func TestListAll_FollowsNextLinks(t *testing.T) {
+	requests := 0
+	handler := pagedHandler(t, "srv", 7, true)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path != "/datacenters/dc1/servers" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		handler(w, r)
+	}))
+	defer server.Close()
+
+	client := &Client{BaseURL: server.URL, Token: "token", PageSize: 3, HTTPClient: server.Client()}
+
+	servers, err := client.GetServers(context.Background(), "dc1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(servers) != 7 {
+		t.Fatalf("expected 7 servers, got %d", len(servers))
+	}
+	if servers[0].ID != "srv-0" || servers[6].ID != "srv-6" {
+		t.Fatalf("unexpected server order: first %s, last %s", servers[0].ID, servers[6].ID)
+	}
+	if requests != 3 {
+		t.Fatalf("expected 3 requests, got %d", requests)
+	}
+}
+
+func TestListAll_FallsBackToOffsetAndLimit(t *testing.T) {
+	requests := 0
+	handler := pagedHandler(t, "dc", 4, false)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		handler(w, r)
+	}))
+	defer server.Close()
+
+	client := &Client{BaseURL: server.URL, Token: "token", PageSize: 2, HTTPClient: server.Client()}
+
+	datacenters, err := client.ListDatacenters(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(datacenters) != 4 {
+		t.Fatalf("expected 4 datacenters, got %d", len(datacenters))
+	}
+	// Two full pages followed by an empty one that ends the iteration.
+	if requests != 3 {
+		t.Fatalf("expected 3 requests, got %d", requests)
+	}
+}
+
+func TestListAll_DBaaSPagination(t *testing.T) {
+	server := httptest.NewServer(pagedHandler(t, "pg", 5, true))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	client.PageSize = 2
+
+	clusters, err := client.ListPostgreSQLClusters(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(clusters) != 5 {
+		t.Fatalf("expected 5 clusters, got %d", len(clusters))
+	}
+}
+
+func TestListAll_RejectsForeignNextLink(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page := Page[K8sCluster]{
+			Items: []K8sCluster{{ID: "c1"}},
+			Links: Links{Next: "https://attacker.example.com/k8s?offset=1"},
+		}
+		_ = json.NewEncoder(w).Encode(page)
+	}))
+	defer server.Close()
+
+	client := &Client{BaseURL: server.URL, Token: "token", HTTPClient: server.Client()}
+
+	if _, err := client.ListK8sClusters(context.Background()); err == nil {
+		t.Fatalf("expected error for pagination link to another host")
+	}
+}
+
+func TestCheckDatacenters_CountsAllPages(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/datacenters", pagedHandler(t, "dc", 1, true))
+	mux.HandleFunc("/datacenters/dc-0/servers", pagedHandler(t, "srv", 250, true))
+	mux.HandleFunc("/datacenters/dc-0/volumes", pagedHandler(t, "vol", 120, false))
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := &Client{BaseURL: server.URL, Token: "token", HTTPClient: server.Client()}
+
+	statuses, err := client.CheckDatacenters(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(statuses) != 1 {
+		t.Fatalf("expected 1 datacenter, got %d", len(statuses))
+	}
+	if len(statuses[0].Servers) != 250 {
+		t.Fatalf("expected 250 servers, got %d", len(statuses[0].Servers))
+	}
+	if len(statuses[0].Volumes) != 120 {
+		t.Fatalf("expected 120 volumes, got %d", len(statuses[0].Volumes))
+	}
+}
@@ -0,0 +1,45 @@
+package ionos
+
+import "sync"
+
+const DefaultConcurrency = 8
+
+func (c *Client) concurrency() int {
+	if c.Concurrency > 0 {
+		return c.Concurrency
+	}
+	return DefaultConcurrency
+}
+
+// mapOrdered applies fn to every item using at most limit goroutines and
+// returns the results in the order of the input.
+func mapOrdered[T, R any](items []T, limit int, fn func(T) R) []R {
+	if len(items) == 0 {
+		return nil
+	}
+	if limit <= 0 || limit > len(items) {
+		limit = len(items)
+	}
+
+	results := make([]R, len(items))
+	indexes := make(chan int)
+
+	var wg sync.WaitGroup
+	for range limit {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				results[i] = fn(items[i])
+			}
+		}()
+	}
+
+	for i := range items {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	return results
+}
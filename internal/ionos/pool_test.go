@@ -0,0 +1,95 @@
+package ionos
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMapOrdered_PreservesOrder(t *testing.T) {
+	items := []int{5, 4, 3, 2, 1, 0}
+
+	results := mapOrdered(items, 3, func(n int) int {
+		time.Sleep(time.Duration(n) * time.Millisecond)
+		return n * 10
+	})
+
+	for i, n := range items {
+		if results[i] != n*10 {
+			t.Fatalf("expected results in input order, got %v", results)
+		}
+	}
+}
+
+func TestMapOrdered_BoundsConcurrency(t *testing.T) {
+	var running, peak atomic.Int32
+
+	mapOrdered(make([]int, 20), 4, func(int) struct{} {
+		current := running.Add(1)
+		for {
+			p := peak.Load()
+			if current <= p || peak.CompareAndSwap(p, current) {
+				break
+			}
+		}
+		time.Sleep(2 * time.Millisecond)
+		running.Add(-1)
+		return struct{}{}
+	})
+
+	if peak.Load() > 4 {
+		t.Fatalf("expected at most 4 concurrent calls, got %d", peak.Load())
+	}
+}
+
+func TestMapOrdered_EmptyInput(t *testing.T) {
+	if results := mapOrdered(nil, 4, func(n int) int { return n }); results != nil {
+		t.Fatalf("expected nil results, got %v", results)
+	}
+}
+
+func TestCheckDatacenters_ConcurrentResultsKeepOrder(t *testing.T) {
+	const count = 12
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/datacenters" {
+			var resp DataCentersResponse
+			for i := range count {
+				dc := DataCenter{ID: fmt.Sprintf("dc-%d", i)}
+				dc.Properties.Name = fmt.Sprintf("DC %d", i)
+				resp.Items = append(resp.Items, dc)
+			}
+			_ = json.NewEncoder(w).Encode(resp)
+			return
+		}
+
+		// Earlier datacenters answer slower so completion order differs from input order.
+		var index int
+		_, _ = fmt.Sscanf(strings.TrimPrefix(r.URL.Path, "/datacenters/dc-"), "%d", &index)
+		time.Sleep(time.Duration(count-index) * time.Millisecond)
+		_ = json.NewEncoder(w).Encode(ServersResponse{})
+	}))
+	defer server.Close()
+
+	client := &Client{BaseURL: server.URL, Token: "token", Concurrency: 4, HTTPClient: server.Client()}
+
+	statuses, err := client.CheckDatacenters(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(statuses) != count {
+		t.Fatalf("expected %d datacenters, got %d", count, len(statuses))
+	}
+	for i, status := range statuses {
+		if status.Datacenter.ID != fmt.Sprintf("dc-%d", i) {
+			t.Fatalf("expected dc-%d at position %d, got %s", i, i, status.Datacenter.ID)
+		}
+	}
+}
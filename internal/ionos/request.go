@@ -0,0 +1,225 @@
+package ionos
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	DefaultMaxRetries   = 3
+	DefaultRetryWaitMin = 500 * time.Millisecond
+	DefaultRetryWaitMax = 10 * time.Second
+
+	maxRetryAfter = 2 * time.Minute
+)
+
+var sleep = sleepContext
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+type StatusError struct {
+	StatusCode int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("API returned status %d", e.StatusCode)
+}
+
+func isNotFound(err error) bool {
+	var statusErr *StatusError
+	return errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusNotFound
+}
+
+func isRetryableStatus(code int) bool {
+	switch code {
+	case http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// do sends a request, retrying transient failures with jittered exponential
+// backoff and honouring the rate-limit headers returned by the IONOS API.
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	for attempt := 0; ; attempt++ {
+		if err := c.waitForRateLimit(ctx); err != nil {
+			return nil, err
+		}
+
+		resp, err := c.HTTPClient.Do(req)
+		if err == nil {
+			c.recordRateLimit(resp)
+		}
+
+		retryable := err != nil || isRetryableStatus(resp.StatusCode)
+		if !retryable || attempt >= c.MaxRetries || ctx.Err() != nil {
+			return resp, err
+		}
+
+		wait := c.backoff(attempt)
+		if resp != nil {
+			if retryAfter, ok := retryAfterDelay(resp); ok {
+				wait = retryAfter
+			}
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}
+
+		if err := sleep(ctx, wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (c *Client) backoff(attempt int) time.Duration {
+	minWait := c.RetryWaitMin
+	if minWait <= 0 {
+		minWait = DefaultRetryWaitMin
+	}
+	maxWait := c.RetryWaitMax
+	if maxWait < minWait {
+		maxWait = minWait
+	}
+
+	wait := minWait << attempt
+	if wait <= 0 || wait > maxWait {
+		wait = maxWait
+	}
+
+	// Full jitter in the upper half keeps concurrent clients from retrying in lockstep.
+	half := wait / 2
+	return half + time.Duration(rand.Int64N(int64(half)+1)) //nolint:gosec // jitter does not need a cryptographic source
+}
+
+// retryAfterDelay reads Retry-After (seconds or HTTP date) and falls back to the
+// IONOS X-RateLimit-Limit header, which is the number of requests per minute.
+func retryAfterDelay(resp *http.Response) (time.Duration, bool) {
+	if value := resp.Header.Get("Retry-After"); value != "" {
+		if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+			return capRetryAfter(time.Duration(seconds) * time.Second), true
+		}
+		if at, err := http.ParseTime(value); err == nil {
+			return capRetryAfter(time.Until(at)), true
+		}
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		if delay, ok := rateLimitInterval(resp.Header); ok {
+			return delay, true
+		}
+	}
+
+	return 0, false
+}
+
+func rateLimitInterval(header http.Header) (time.Duration, bool) {
+	limit, err := strconv.Atoi(header.Get("X-RateLimit-Limit"))
+	if err != nil || limit <= 0 {
+		return 0, false
+	}
+	return time.Minute / time.Duration(limit), true
+}
+
+func capRetryAfter(d time.Duration) time.Duration {
+	if d < 0 {
+		return 0
+	}
+	if d > maxRetryAfter {
+		return maxRetryAfter
+	}
+	return d
+}
+
+func (c *Client) recordRateLimit(resp *http.Response) {
+	if resp.Header.Get("X-RateLimit-Remaining") != "0" {
+		return
+	}
+	delay, ok := rateLimitInterval(resp.Header)
+	if !ok {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.throttledUntil = time.Now().Add(delay)
+}
+
+func (c *Client) waitForRateLimit(ctx context.Context) error {
+	c.mu.Lock()
+	wait := time.Until(c.throttledUntil)
+	c.mu.Unlock()
+
+	if wait > 0 {
+		return sleep(ctx, wait)
+	}
+	return nil
+}
+
+var errPayloadTooLarge = errors.New("response payload too large")
+
+func (c *Client) getJSON(ctx context.Context, url string, result interface{}) error {
+	return c.getJSONLimited(ctx, url, result, 0)
+}
+
+// getJSONLimited fails with errPayloadTooLarge once the response body exceeds
+// maxBytes; a maxBytes of 0 disables the limit.
+func (c *Client) getJSONLimited(ctx context.Context, url string, result interface{}, maxBytes int64) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return err
+	}
+
+	c.setAuth(req)
+
+	resp, err := c.do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusRequestEntityTooLarge {
+		return errPayloadTooLarge
+	}
+	if resp.StatusCode != http.StatusOK {
+		return &StatusError{StatusCode: resp.StatusCode}
+	}
+
+	if maxBytes <= 0 {
+		return json.NewDecoder(resp.Body).Decode(result)
+	}
+	if resp.ContentLength > maxBytes {
+		return errPayloadTooLarge
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxBytes+1))
+	if err != nil {
+		return err
+	}
+	if int64(len(data)) > maxBytes {
+		return errPayloadTooLarge
+	}
+	return json.Unmarshal(data, result)
+}
@@ -0,0 +1,223 @@
+package ionos
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func stubSleep(t *testing.T) *[]time.Duration {
+	t.Helper()
+	var waits []time.Duration
+	orig := sleep
+	sleep = func(_ context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		return nil
+	}
+	t.Cleanup(func() { sleep = orig })
+	return &waits
+}
+
+func newRetryClient(server *httptest.Server) *Client {
+	return &Client{
+		BaseURL:      server.URL,
+		Token:        "token",
+		MaxRetries:   3,
+		RetryWaitMin: 100 * time.Millisecond,
+		RetryWaitMax: time.Second,
+		HTTPClient:   server.Client(),
+	}
+}
+
+func TestDo_RetriesServerErrors(t *testing.T) {
+	waits := stubSleep(t)
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(Page[K8sCluster]{Items: []K8sCluster{{ID: "c1"}}})
+	}))
+	defer server.Close()
+
+	clusters, err := newRetryClient(server).ListK8sClusters(context.Background())
+	if err != nil {
+		t.Fatalf("expected retries to succeed, got: %v", err)
+	}
+	if len(clusters) != 1 {
+		t.Fatalf("expected 1 cluster, got %d", len(clusters))
+	}
+	if requests != 3 {
+		t.Fatalf("expected 3 requests, got %d", requests)
+	}
+	if len(*waits) != 2 {
+		t.Fatalf("expected 2 backoff waits, got %v", *waits)
+	}
+	for i, wait := range *waits {
+		maxWait := 100 * time.Millisecond << i
+		if wait < maxWait/2 || wait > maxWait {
+			t.Fatalf("backoff %d = %s, expected between %s and %s", i, wait, maxWait/2, maxWait)
+		}
+	}
+}
+
+func TestDo_GivesUpAfterMaxRetries(t *testing.T) {
+	waits := stubSleep(t)
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	_, err := newRetryClient(server).ListDatacenters(context.Background())
+	if err == nil || err.Error() != "API returned status 502" {
+		t.Fatalf("expected status error, got: %v", err)
+	}
+	if requests != 4 {
+		t.Fatalf("expected 1 request and 3 retries, got %d requests", requests)
+	}
+	if len(*waits) != 3 {
+		t.Fatalf("expected 3 waits, got %v", *waits)
+	}
+}
+
+func TestDo_DoesNotRetryClientErrors(t *testing.T) {
+	waits := stubSleep(t)
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	result := newRetryClient(server).CheckAuthentication(context.Background())
+	if result.OK {
+		t.Fatalf("expected authentication to fail")
+	}
+	if requests != 1 || len(*waits) != 0 {
+		t.Fatalf("expected no retries, got %d requests and waits %v", requests, *waits)
+	}
+}
+
+func TestDo_HonoursRetryAfter(t *testing.T) {
+	waits := stubSleep(t)
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 1 {
+			w.Header().Set("Retry-After", "7")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(Page[DataCenter]{})
+	}))
+	defer server.Close()
+
+	if _, err := newRetryClient(server).ListDatacenters(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(*waits) != 1 || (*waits)[0] != 7*time.Second {
+		t.Fatalf("expected a single 7s wait, got %v", *waits)
+	}
+}
+
+func TestDo_UsesRateLimitHeaderOn429(t *testing.T) {
+	waits := stubSleep(t)
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 1 {
+			w.Header().Set("X-RateLimit-Limit", "120")
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(Page[DataCenter]{})
+	}))
+	defer server.Close()
+
+	client := newRetryClient(server)
+	if _, err := client.ListDatacenters(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// The exhausted budget throttles the retry, which then waits for one request slot.
+	var total time.Duration
+	for _, wait := range *waits {
+		total += wait
+	}
+	if total < 500*time.Millisecond {
+		t.Fatalf("expected at least one 500ms rate-limit wait, got %v", *waits)
+	}
+}
+
+func TestDo_ThrottlesWhenBudgetExhausted(t *testing.T) {
+	waits := stubSleep(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "60")
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		_ = json.NewEncoder(w).Encode(Page[DataCenter]{})
+	}))
+	defer server.Close()
+
+	client := newRetryClient(server)
+	if _, err := client.ListDatacenters(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(*waits) != 0 {
+		t.Fatalf("expected no wait before the first request, got %v", *waits)
+	}
+
+	if _, err := client.ListDatacenters(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(*waits) != 1 || (*waits)[0] <= 0 || (*waits)[0] > time.Second {
+		t.Fatalf("expected a single wait of up to 1s, got %v", *waits)
+	}
+}
+
+func TestRetryAfterDelay_HTTPDate(t *testing.T) {
+	resp := &http.Response{
+		StatusCode: http.StatusServiceUnavailable,
+		Header:     http.Header{"Retry-After": []string{time.Now().Add(30 * time.Second).UTC().Format(http.TimeFormat)}},
+	}
+
+	delay, ok := retryAfterDelay(resp)
+	if !ok {
+		t.Fatalf("expected Retry-After date to be parsed")
+	}
+	if delay < 28*time.Second || delay > 30*time.Second {
+		t.Fatalf("unexpected delay: %s", delay)
+	}
+}
+
+func TestDo_StopsRetryingWhenContextCancelled(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	orig := sleep
+	sleep = func(_ context.Context, _ time.Duration) error {
+		cancel()
+		return ctx.Err()
+	}
+	t.Cleanup(func() { sleep = orig })
+
+	_, err := newRetryClient(server).ListDatacenters(ctx)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got: %v", err)
+	}
+	if requests != 1 {
+		t.Fatalf("expected no retries after cancellation, got %d requests", requests)
+	}
+}
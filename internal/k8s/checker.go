@@ -9,6 +9,7 @@ import (
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/clientcmd"
@@ -17,6 +18,27 @@ import (
 
 type Checker struct {
 	client kubernetes.Interface
+	opts   Options
+}
+
+// Options tune individual checks. Zero values select the defaults.
+type Options struct {
+	// EventLookback is how far back warning events and container
+	// terminations are collected.
+	EventLookback time.Duration
+	// RestartThreshold is the number of container restarts from which a
+	// recently restarted container is reported.
+	RestartThreshold int
+	// NotReadyAfter is how long a running pod may be not ready before it is
+	// reported.
+	NotReadyAfter time.Duration
+}
+
+func (o Options) eventLookback() time.Duration {
+	if o.EventLookback > 0 {
+		return o.EventLookback
+	}
+	return DefaultEventLookback
 }
 
 type quietWarningHandler struct{}
@@ -24,13 +46,46 @@ type quietWarningHandler struct{}
 func (quietWarningHandler) HandleWarningHeader(code int, agent string, text string) {}
 
 type HealthResult struct {
-	Nodes       NodeResult
-	Pods        PodResult
-	Deployments DeploymentResult
-	PVCs        PVCResult
-	Services    ServiceResult
-	Events      EventResult
-	Certs       CertResult
+	Nodes        NodeResult
+	Pods         PodResult
+	Deployments  DeploymentResult
+	StatefulSets StatefulSetResult
+	DaemonSets   DaemonSetResult
+	Jobs         JobResult
+	CronJobs     CronJobResult
+	HPAs         HPAResult
+	PDBs         PDBResult
+	PVCs         PVCResult
+	Services     ServiceResult
+	Endpoints    EndpointResult
+	Events       EventResult
+	Certs        CertResult
+	// Errors lists optional checks the watchdog may not list the resources
+	// of. Their results are left empty while the other checks are still
+	// reported.
+	Errors []CheckError
+}
+
+// CheckError is an optional check whose list was forbidden. Check names it
+// after the resources it covers, e.g. "statefulsets".
+type CheckError struct {
+	Check string
+	Err   error
+}
+
+func (e CheckError) Error() string {
+	return e.Err.Error()
+}
+
+// addError records a forbidden optional check so that the other checks still
+// run. Any other error is returned and fails the health check as a whole.
+func (r *HealthResult) addError(check string, err error) error {
+	err = fmt.Errorf("failed to check %s: %w", check, err)
+	if !apierrors.IsForbidden(err) {
+		return err
+	}
+	r.Errors = append(r.Errors, CheckError{Check: check, Err: err})
+	return nil
 }
 
 type NodeResult struct {
@@ -40,15 +95,6 @@ type NodeResult struct {
 	Conditions []string
 }
 
-type PodResult struct {
-	Total            int
-	Running          int
-	CrashLoopBackOff []string
-	ImagePullBackOff []string
-	Pending          []string
-	Failed           []string
-}
-
 type DeploymentResult struct {
 	Total       int
 	Available   int
@@ -67,10 +113,6 @@ type ServiceResult struct {
 	NoIP  []string
 }
 
-type EventResult struct {
-	Warnings []string
-}
-
 type CertInfo struct {
 	Host      string
 	Namespace string
@@ -84,9 +126,12 @@ type CertResult struct {
 	Valid    int
 	Expiring []CertInfo
 	Expired  []CertInfo
+	// MinExpiresIn is the smallest ExpiresIn of all certificates, only
+	// meaningful when Total > 0.
+	MinExpiresIn int
 }
 
-func NewChecker(kubeconfigPath string) (*Checker, error) {
+func NewChecker(kubeconfigPath string, opts Options) (*Checker, error) {
 	if kubeconfigPath == "" {
 		if home := homedir.HomeDir(); home != "" {
 			kubeconfigPath = filepath.Join(home, ".kube", "config")
@@ -106,7 +151,7 @@ func NewChecker(kubeconfigPath string) (*Checker, error) {
 		return nil, fmt.Errorf("failed to create kubernetes client: %w", err)
 	}
 
-	return &Checker{client: clientset}, nil
+	return &Checker{client: clientset, opts: opts}, nil
 }
 
 func (c *Checker) CheckHealth(ctx context.Context, namespace string) (*HealthResult, error) {
@@ -130,6 +175,47 @@ func (c *Checker) CheckHealth(ctx context.Context, namespace string) (*HealthRes
 	}
 	result.Deployments = *deployResult
 
+	if stsResult, err := c.checkStatefulSets(ctx, namespace); err != nil {
+		if err := result.addError("statefulsets", err); err != nil {
+			return nil, err
+		}
+	} else {
+		result.StatefulSets = *stsResult
+	}
+
+	if dsResult, err := c.checkDaemonSets(ctx, namespace); err != nil {
+		if err := result.addError("daemonsets", err); err != nil {
+			return nil, err
+		}
+	} else {
+		result.DaemonSets = *dsResult
+	}
+
+	if jobResult, cronJobResult, err := c.checkJobs(ctx, namespace); err != nil {
+		if err := result.addError("jobs", err); err != nil {
+			return nil, err
+		}
+	} else {
+		result.Jobs = *jobResult
+		result.CronJobs = *cronJobResult
+	}
+
+	if hpaResult, err := c.checkHPAs(ctx, namespace); err != nil {
+		if err := result.addError("hpas", err); err != nil {
+			return nil, err
+		}
+	} else {
+		result.HPAs = *hpaResult
+	}
+
+	if pdbResult, err := c.checkPDBs(ctx, namespace); err != nil {
+		if err := result.addError("pdbs", err); err != nil {
+			return nil, err
+		}
+	} else {
+		result.PDBs = *pdbResult
+	}
+
 	pvcResult, err := c.checkPVCs(ctx, namespace)
 	if err != nil {
 		return nil, fmt.Errorf("failed to check pvcs: %w", err)
@@ -142,11 +228,30 @@ func (c *Checker) CheckHealth(ctx context.Context, namespace string) (*HealthRes
 	}
 	result.Services = *svcResult
 
-	eventResult, err := c.checkEvents(ctx, namespace)
-	if err != nil {
-		return nil, fmt.Errorf("failed to check events: %w", err)
+	// Ingress backends can only be checked with the endpoints of their
+	// services.
+	if endpointResult, endpoints, err := c.checkEndpoints(ctx, namespace); err != nil {
+		if err := result.addError("endpoints", err); err != nil {
+			return nil, err
+		}
+		// Only a forbidden list gets here, which skips the ingresses too.
+		_ = result.addError("ingresses", err)
+	} else {
+		result.Endpoints = *endpointResult
+		if err := c.checkIngresses(ctx, namespace, endpoints, &result.Endpoints); err != nil {
+			if err := result.addError("ingresses", err); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if eventResult, err := c.checkEvents(ctx, namespace); err != nil {
+		if err := result.addError("events", err); err != nil {
+			return nil, err
+		}
+	} else {
+		result.Events = *eventResult
 	}
-	result.Events = *eventResult
 
 	certResult, err := c.checkCertificates(ctx, namespace)
 	if err != nil {
@@ -193,50 +298,6 @@ func (c *Checker) checkNodes(ctx context.Context) (*NodeResult, error) {
 	return result, nil
 }
 
-func (c *Checker) checkPods(ctx context.Context, namespace string) (*PodResult, error) {
-	pods, err := c.client.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
-	if err != nil {
-		return nil, err
-	}
-
-	result := &PodResult{
-		Total: len(pods.Items),
-	}
-
-	for _, pod := range pods.Items {
-		podName := fmt.Sprintf("%s/%s", pod.Namespace, pod.Name)
-
-		switch pod.Status.Phase {
-		case corev1.PodRunning:
-			hasIssue := false
-			for _, cs := range pod.Status.ContainerStatuses {
-				if cs.State.Waiting != nil {
-					reason := cs.State.Waiting.Reason
-					switch reason {
-					case "CrashLoopBackOff":
-						result.CrashLoopBackOff = append(result.CrashLoopBackOff, podName)
-						hasIssue = true
-					case "ImagePullBackOff", "ErrImagePull":
-						result.ImagePullBackOff = append(result.ImagePullBackOff, podName)
-						hasIssue = true
-					}
-				}
-			}
-			if !hasIssue {
-				result.Running++
-			}
-		case corev1.PodPending:
-			result.Pending = append(result.Pending, podName)
-		case corev1.PodFailed:
-			result.Failed = append(result.Failed, podName)
-		default:
-			result.Running++
-		}
-	}
-
-	return result, nil
-}
-
 func (c *Checker) checkDeployments(ctx context.Context, namespace string) (*DeploymentResult, error) {
 	deployments, err := c.client.AppsV1().Deployments(namespace).List(ctx, metav1.ListOptions{})
 	if err != nil {
@@ -310,33 +371,6 @@ func (c *Checker) checkServices(ctx context.Context, namespace string) (*Service
 	return result, nil
 }
 
-func (c *Checker) checkEvents(ctx context.Context, namespace string) (*EventResult, error) {
-	events, err := c.client.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{
-		FieldSelector: "type=Warning",
-	})
-	if err != nil {
-		return nil, err
-	}
-
-	result := &EventResult{}
-
-	cutoff := time.Now().Add(-1 * time.Hour)
-
-	for _, event := range events.Items {
-		eventTime := event.LastTimestamp.Time
-		if eventTime.IsZero() {
-			eventTime = event.EventTime.Time
-		}
-
-		if eventTime.After(cutoff) {
-			msg := fmt.Sprintf("%s/%s: %s", event.InvolvedObject.Namespace, event.InvolvedObject.Name, event.Message)
-			result.Warnings = append(result.Warnings, msg)
-		}
-	}
-
-	return result, nil
-}
-
 func (c *Checker) checkCertificates(ctx context.Context, namespace string) (*CertResult, error) {
 	result := &CertResult{}
 
@@ -379,8 +413,11 @@ func (c *Checker) checkCertificates(ctx context.Context, namespace string) (*Cer
 				continue
 			}
 
-			result.Total++
 			daysUntilExpiry := int(time.Until(cert.NotAfter).Hours() / 24)
+			if result.Total == 0 || daysUntilExpiry < result.MinExpiresIn {
+				result.MinExpiresIn = daysUntilExpiry
+			}
+			result.Total++
 
 			host := ""
 			if len(tls.Hosts) > 0 {
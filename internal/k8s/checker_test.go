@@ -8,14 +8,19 @@ import (
 	"crypto/x509/pkix"
 	"encoding/pem"
 	"math/big"
+	"strings"
 	"testing"
 	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
 )
 
 func TestCheckHealth_AggregatesClusterState(t *testing.T) {
@@ -206,13 +211,61 @@ func TestCheckHealth_AggregatesClusterState(t *testing.T) {
 		t.Fatalf("unexpected events: %+v", result.Events)
 	}
 
-	if result.Certs.Total != 3 || result.Certs.Valid != 1 {
+	if result.Certs.Total != 3 || result.Certs.Valid != 1 || result.Certs.MinExpiresIn >= 0 {
 		t.Fatalf("unexpected cert counts: %+v", result.Certs)
 	}
 	assertContains(t, hostList(result.Certs.Expiring), "soon.example.com")
 	assertContains(t, hostList(result.Certs.Expired), "old.example.com")
 }
 
+func TestCheckHealth_KeepsResultsWhenOptionalListIsForbidden(t *testing.T) {
+	client := fake.NewSimpleClientset(&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}})
+
+	for _, forbidden := range []schema.GroupResource{
+		{Group: "apps", Resource: "statefulsets"},
+		{Group: "apps", Resource: "daemonsets"},
+		{Group: "batch", Resource: "cronjobs"},
+		{Group: "autoscaling", Resource: "horizontalpodautoscalers"},
+		{Group: "policy", Resource: "poddisruptionbudgets"},
+		{Group: "discovery.k8s.io", Resource: "endpointslices"},
+		{Group: "", Resource: "events"},
+	} {
+		client.PrependReactor("list", forbidden.Resource, func(k8stesting.Action) (bool, runtime.Object, error) {
+			return true, nil, apierrors.NewForbidden(forbidden, "", nil)
+		})
+	}
+
+	result, err := (&Checker{client: client}).CheckHealth(context.Background(), "default")
+	if err != nil {
+		t.Fatalf("CheckHealth returned error: %v", err)
+	}
+
+	if result.Nodes.Total != 1 {
+		t.Fatalf("expected the node check to be kept, got %+v", result.Nodes)
+	}
+	want := []string{"statefulsets", "daemonsets", "jobs", "hpas", "pdbs", "endpoints", "ingresses", "events"}
+	if len(result.Errors) != len(want) {
+		t.Fatalf("expected %d check errors, got %v", len(want), result.Errors)
+	}
+	for i, check := range want {
+		if result.Errors[i].Check != check || !apierrors.IsForbidden(result.Errors[i].Err) {
+			t.Errorf("check error %d: expected forbidden %s, got %v", i, check, result.Errors[i])
+		}
+	}
+}
+
+func TestCheckHealth_FailsWhenOptionalListErrs(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	client.PrependReactor("list", "statefulsets", func(k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrors.NewServiceUnavailable("etcd leader changed")
+	})
+
+	_, err := (&Checker{client: client}).CheckHealth(context.Background(), "default")
+	if err == nil || !strings.Contains(err.Error(), "failed to check statefulsets") || !apierrors.IsServiceUnavailable(err) {
+		t.Fatalf("expected a non-forbidden list error to fail the health check, got %v", err)
+	}
+}
+
 func mustCertPEM(t *testing.T, notAfter time.Time) []byte {
 	t.Helper()
 
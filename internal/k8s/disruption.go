@@ -0,0 +1,121 @@
+package k8s
+
+import (
+	"context"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type HPAResult struct {
+	Total   int
+	Healthy int
+	// ScalingInactive lists HPAs that cannot compute a replica count,
+	// usually because their metrics are missing. They are not listed in
+	// AtMax. HPAs whose target is scaled to zero count as healthy.
+	ScalingInactive []HPAIssue
+	AtMax           []HPAIssue
+}
+
+type HPAIssue struct {
+	Namespace       string
+	Name            string
+	CurrentReplicas int32
+	MaxReplicas     int32
+	Reason          string
+	Message         string
+}
+
+type PDBResult struct {
+	Total   int
+	Healthy int
+	// Blocking lists PDBs that currently allow no disruption, which stalls
+	// node drains such as those of IONOS node pool maintenance.
+	Blocking []PDBIssue
+}
+
+type PDBIssue struct {
+	Namespace      string
+	Name           string
+	CurrentHealthy int32
+	DesiredHealthy int32
+	ExpectedPods   int32
+}
+
+func (c *Checker) checkHPAs(ctx context.Context, namespace string) (*HPAResult, error) {
+	hpas, err := c.client.AutoscalingV2().HorizontalPodAutoscalers(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &HPAResult{
+		Total: len(hpas.Items),
+	}
+
+	for _, hpa := range hpas.Items {
+		issue := HPAIssue{
+			Namespace:       hpa.Namespace,
+			Name:            hpa.Name,
+			CurrentReplicas: hpa.Status.CurrentReplicas,
+			MaxReplicas:     hpa.Spec.MaxReplicas,
+		}
+
+		// ScalingDisabled means the target was deliberately scaled to zero.
+		if condition := hpaCondition(hpa, autoscalingv2.ScalingActive); condition != nil && condition.Status == corev1.ConditionFalse && condition.Reason != "ScalingDisabled" {
+			issue.Reason = condition.Reason
+			issue.Message = condition.Message
+			result.ScalingInactive = append(result.ScalingInactive, issue)
+			continue
+		}
+		if hpa.Spec.MaxReplicas > 0 && hpa.Status.CurrentReplicas >= hpa.Spec.MaxReplicas {
+			if condition := hpaCondition(hpa, autoscalingv2.ScalingLimited); condition != nil && condition.Status == corev1.ConditionTrue {
+				issue.Reason = condition.Reason
+				issue.Message = condition.Message
+			}
+			result.AtMax = append(result.AtMax, issue)
+			continue
+		}
+		result.Healthy++
+	}
+
+	return result, nil
+}
+
+func hpaCondition(hpa autoscalingv2.HorizontalPodAutoscaler, conditionType autoscalingv2.HorizontalPodAutoscalerConditionType) *autoscalingv2.HorizontalPodAutoscalerCondition {
+	for i, condition := range hpa.Status.Conditions {
+		if condition.Type == conditionType {
+			return &hpa.Status.Conditions[i]
+		}
+	}
+	return nil
+}
+
+func (c *Checker) checkPDBs(ctx context.Context, namespace string) (*PDBResult, error) {
+	pdbs, err := c.client.PolicyV1().PodDisruptionBudgets(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &PDBResult{
+		Total: len(pdbs.Items),
+	}
+
+	for _, pdb := range pdbs.Items {
+		// A PDB selecting no pods allows no disruptions, but also has
+		// nothing to protect.
+		if pdb.Status.DisruptionsAllowed == 0 && pdb.Status.ExpectedPods > 0 {
+			result.Blocking = append(result.Blocking, PDBIssue{
+				Namespace:      pdb.Namespace,
+				Name:           pdb.Name,
+				CurrentHealthy: pdb.Status.CurrentHealthy,
+				DesiredHealthy: pdb.Status.DesiredHealthy,
+				ExpectedPods:   pdb.Status.ExpectedPods,
+			})
+			continue
+		}
+		result.Healthy++
+	}
+
+	return result, nil
+}
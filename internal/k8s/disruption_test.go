@@ -0,0 +1,80 @@
+package k8s
+
+import (
+	"context"
+	"testing"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestCheckHPAs(t *testing.T) {
+	hpa := func(name string, current, max int32, conditions ...autoscalingv2.HorizontalPodAutoscalerCondition) *autoscalingv2.HorizontalPodAutoscaler {
+		return &autoscalingv2.HorizontalPodAutoscaler{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "web"},
+			Spec:       autoscalingv2.HorizontalPodAutoscalerSpec{MaxReplicas: max},
+			Status:     autoscalingv2.HorizontalPodAutoscalerStatus{CurrentReplicas: current, Conditions: conditions},
+		}
+	}
+
+	client := fake.NewSimpleClientset(
+		hpa("api", 3, 10, autoscalingv2.HorizontalPodAutoscalerCondition{Type: autoscalingv2.ScalingActive, Status: corev1.ConditionTrue}),
+		hpa("frontend", 10, 10, autoscalingv2.HorizontalPodAutoscalerCondition{Type: autoscalingv2.ScalingLimited, Status: corev1.ConditionTrue, Reason: "TooManyReplicas"}),
+		hpa("worker", 10, 10, autoscalingv2.HorizontalPodAutoscalerCondition{
+			Type: autoscalingv2.ScalingActive, Status: corev1.ConditionFalse, Reason: "FailedGetResourceMetric", Message: "missing request for cpu",
+		}),
+		hpa("batch", 0, 10, autoscalingv2.HorizontalPodAutoscalerCondition{
+			Type: autoscalingv2.ScalingActive, Status: corev1.ConditionFalse, Reason: "ScalingDisabled", Message: "scaling is disabled since the replica count of the target is zero",
+		}),
+	)
+
+	result, err := (&Checker{client: client}).checkHPAs(context.Background(), "web")
+	if err != nil {
+		t.Fatalf("checkHPAs returned error: %v", err)
+	}
+
+	if result.Total != 4 || result.Healthy != 2 {
+		t.Fatalf("unexpected hpa counts: %+v", result)
+	}
+	if len(result.AtMax) != 1 || result.AtMax[0].Name != "frontend" || result.AtMax[0].Reason != "TooManyReplicas" {
+		t.Fatalf("unexpected HPAs at max: %+v", result.AtMax)
+	}
+	if len(result.ScalingInactive) != 1 || result.ScalingInactive[0].Name != "worker" || result.ScalingInactive[0].Reason != "FailedGetResourceMetric" {
+		t.Fatalf("unexpected inactive HPAs: %+v", result.ScalingInactive)
+	}
+}
+
+func TestCheckPDBs(t *testing.T) {
+	pdb := func(name string, allowed, healthy, desired, expected int32) *policyv1.PodDisruptionBudget {
+		return &policyv1.PodDisruptionBudget{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "db"},
+			Status: policyv1.PodDisruptionBudgetStatus{
+				DisruptionsAllowed: allowed,
+				CurrentHealthy:     healthy,
+				DesiredHealthy:     desired,
+				ExpectedPods:       expected,
+			},
+		}
+	}
+
+	client := fake.NewSimpleClientset(
+		pdb("api", 1, 3, 2, 3),
+		pdb("postgres", 0, 1, 1, 1),
+		pdb("orphan", 0, 0, 0, 0),
+	)
+
+	result, err := (&Checker{client: client}).checkPDBs(context.Background(), "db")
+	if err != nil {
+		t.Fatalf("checkPDBs returned error: %v", err)
+	}
+
+	if result.Total != 3 || result.Healthy != 2 {
+		t.Fatalf("unexpected pdb counts: %+v", result)
+	}
+	if len(result.Blocking) != 1 || result.Blocking[0].Name != "postgres" || result.Blocking[0].ExpectedPods != 1 {
+		t.Fatalf("unexpected blocking PDBs: %+v", result.Blocking)
+	}
+}
@@ -0,0 +1,149 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EndpointResult covers services with a selector, of any type, and the
+// services ingresses route to.
+type EndpointResult struct {
+	Total       int
+	Ready       int
+	NoEndpoints []string
+
+	Ingresses      int
+	IngressesReady int
+	// IngressNoBackends lists ingresses with at least one backend service
+	// that has no ready endpoints or does not exist.
+	IngressNoBackends []IngressIssue
+}
+
+type IngressIssue struct {
+	Namespace string
+	Name      string
+	Services  []string
+}
+
+// serviceEndpoints holds the ready endpoint count and type of each service by
+// "namespace/name", for checking the backends of ingresses.
+type serviceEndpoints struct {
+	ready map[string]int
+	types map[string]corev1.ServiceType
+}
+
+func (c *Checker) checkEndpoints(ctx context.Context, namespace string) (*EndpointResult, *serviceEndpoints, error) {
+	services, err := c.client.CoreV1().Services(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	endpointSlices, err := c.client.DiscoveryV1().EndpointSlices(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ready := make(map[string]int)
+	for _, slice := range endpointSlices.Items {
+		svcName := slice.Labels[discoveryv1.LabelServiceName]
+		if svcName == "" {
+			continue
+		}
+		ready[slice.Namespace+"/"+svcName] += readyEndpoints(slice)
+	}
+
+	result := &EndpointResult{}
+	serviceTypes := make(map[string]corev1.ServiceType)
+
+	for _, svc := range services.Items {
+		svcName := fmt.Sprintf("%s/%s", svc.Namespace, svc.Name)
+		serviceTypes[svcName] = svc.Spec.Type
+
+		// Services without a selector get their endpoints from elsewhere,
+		// e.g. an operator or a manually managed EndpointSlice.
+		if svc.Spec.Type == corev1.ServiceTypeExternalName || len(svc.Spec.Selector) == 0 {
+			continue
+		}
+
+		result.Total++
+		if ready[svcName] > 0 {
+			result.Ready++
+		} else {
+			result.NoEndpoints = append(result.NoEndpoints, svcName)
+		}
+	}
+
+	return result, &serviceEndpoints{ready: ready, types: serviceTypes}, nil
+}
+
+// checkIngresses adds the ingresses to result, whose services have been
+// checked by checkEndpoints.
+func (c *Checker) checkIngresses(ctx context.Context, namespace string, endpoints *serviceEndpoints, result *EndpointResult) error {
+	ingresses, err := c.client.NetworkingV1().Ingresses(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	for _, ing := range ingresses.Items {
+		result.Ingresses++
+
+		var missing []string
+		for _, backend := range ingressServices(ing) {
+			svcName := ing.Namespace + "/" + backend
+			if endpoints.types[svcName] == corev1.ServiceTypeExternalName || endpoints.ready[svcName] > 0 {
+				continue
+			}
+			missing = append(missing, backend)
+		}
+
+		if len(missing) == 0 {
+			result.IngressesReady++
+		} else {
+			result.IngressNoBackends = append(result.IngressNoBackends, IngressIssue{Namespace: ing.Namespace, Name: ing.Name, Services: missing})
+		}
+	}
+
+	return nil
+}
+
+// readyEndpoints counts the endpoints of a slice that accept traffic. A nil
+// ready condition means unknown and is to be treated as ready.
+func readyEndpoints(slice discoveryv1.EndpointSlice) int {
+	count := 0
+	for _, endpoint := range slice.Endpoints {
+		if endpoint.Conditions.Ready == nil || *endpoint.Conditions.Ready {
+			count++
+		}
+	}
+	return count
+}
+
+// ingressServices returns the distinct backend services of an ingress in the
+// order they first appear.
+func ingressServices(ing networkingv1.Ingress) []string {
+	var names []string
+	seen := make(map[string]bool)
+	addBackend := func(backend *networkingv1.IngressBackend) {
+		if backend == nil || backend.Service == nil || seen[backend.Service.Name] {
+			return
+		}
+		seen[backend.Service.Name] = true
+		names = append(names, backend.Service.Name)
+	}
+
+	addBackend(ing.Spec.DefaultBackend)
+	for _, rule := range ing.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+		for i := range rule.HTTP.Paths {
+			addBackend(&rule.HTTP.Paths[i].Backend)
+		}
+	}
+	return names
+}
@@ -0,0 +1,128 @@
+package k8s
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestCheckEndpoints(t *testing.T) {
+	service := func(name string, svcType corev1.ServiceType, selector map[string]string) *corev1.Service {
+		return &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "web"},
+			Spec:       corev1.ServiceSpec{Type: svcType, Selector: selector},
+		}
+	}
+	slice := func(name, svc string, ready ...*bool) *discoveryv1.EndpointSlice {
+		s := &discoveryv1.EndpointSlice{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "web", Labels: map[string]string{discoveryv1.LabelServiceName: svc}},
+		}
+		for _, r := range ready {
+			s.Endpoints = append(s.Endpoints, discoveryv1.Endpoint{Conditions: discoveryv1.EndpointConditions{Ready: r}})
+		}
+		return s
+	}
+	backend := func(svc string) networkingv1.IngressBackend {
+		return networkingv1.IngressBackend{Service: &networkingv1.IngressServiceBackend{Name: svc}}
+	}
+	ingress := func(name string, backends ...string) *networkingv1.Ingress {
+		paths := []networkingv1.HTTPIngressPath{}
+		for _, b := range backends {
+			paths = append(paths, networkingv1.HTTPIngressPath{Path: "/" + b, Backend: backend(b)})
+		}
+		return &networkingv1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "web"},
+			Spec: networkingv1.IngressSpec{Rules: []networkingv1.IngressRule{{
+				IngressRuleValue: networkingv1.IngressRuleValue{HTTP: &networkingv1.HTTPIngressRuleValue{Paths: paths}},
+			}}},
+		}
+	}
+	ready, notReady := true, false
+	app := map[string]string{"app": "x"}
+
+	client := fake.NewSimpleClientset(
+		service("api", corev1.ServiceTypeClusterIP, app),
+		service("frontend", corev1.ServiceTypeLoadBalancer, app),
+		service("worker", corev1.ServiceTypeNodePort, app),
+		service("external", corev1.ServiceTypeExternalName, nil),
+		service("manual", corev1.ServiceTypeClusterIP, nil),
+		slice("api-1", "api", &notReady),
+		slice("api-2", "api", nil),
+		slice("frontend-1", "frontend", &ready),
+		slice("worker-1", "worker", &notReady),
+		ingress("public", "api", "frontend", "api"),
+		ingress("internal", "worker", "missing", "external"),
+	)
+
+	checker := &Checker{client: client}
+	result, endpoints, err := checker.checkEndpoints(context.Background(), "web")
+	if err != nil {
+		t.Fatalf("checkEndpoints returned error: %v", err)
+	}
+	if err := checker.checkIngresses(context.Background(), "web", endpoints, result); err != nil {
+		t.Fatalf("checkIngresses returned error: %v", err)
+	}
+
+	if result.Total != 3 || result.Ready != 2 {
+		t.Fatalf("unexpected service counts: %+v", result)
+	}
+	if !slices.Equal(result.NoEndpoints, []string{"web/worker"}) {
+		t.Fatalf("unexpected services without endpoints: %v", result.NoEndpoints)
+	}
+	if result.Ingresses != 2 || result.IngressesReady != 1 {
+		t.Fatalf("unexpected ingress counts: %+v", result)
+	}
+	if len(result.IngressNoBackends) != 1 || result.IngressNoBackends[0].Name != "internal" ||
+		!slices.Equal(result.IngressNoBackends[0].Services, []string{"worker", "missing"}) {
+		t.Fatalf("unexpected ingresses without backends: %+v", result.IngressNoBackends)
+	}
+}
+
+func TestCheckEndpoints_KeepsServicesWhenIngressesAreForbidden(t *testing.T) {
+	client := fake.NewSimpleClientset(&corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "web"},
+		Spec:       corev1.ServiceSpec{Selector: map[string]string{"app": "api"}},
+	})
+	client.PrependReactor("list", "ingresses", func(k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrors.NewForbidden(schema.GroupResource{Group: "networking.k8s.io", Resource: "ingresses"}, "", nil)
+	})
+
+	checker := &Checker{client: client}
+	result, endpoints, err := checker.checkEndpoints(context.Background(), "web")
+	if err != nil {
+		t.Fatalf("checkEndpoints returned error: %v", err)
+	}
+	if err := checker.checkIngresses(context.Background(), "web", endpoints, result); !apierrors.IsForbidden(err) {
+		t.Fatalf("expected a forbidden error, got %v", err)
+	}
+	if result.Total != 1 || !slices.Equal(result.NoEndpoints, []string{"web/api"}) {
+		t.Fatalf("expected the service to be checked, got %+v", result)
+	}
+}
+
+func TestIngressServices_DefaultBackend(t *testing.T) {
+	ing := networkingv1.Ingress{Spec: networkingv1.IngressSpec{
+		DefaultBackend: &networkingv1.IngressBackend{Service: &networkingv1.IngressServiceBackend{Name: "fallback"}},
+		Rules: []networkingv1.IngressRule{
+			{Host: "tls-only"},
+			{IngressRuleValue: networkingv1.IngressRuleValue{HTTP: &networkingv1.HTTPIngressRuleValue{Paths: []networkingv1.HTTPIngressPath{
+				{Backend: networkingv1.IngressBackend{Service: &networkingv1.IngressServiceBackend{Name: "api"}}},
+				{Backend: networkingv1.IngressBackend{Resource: &corev1.TypedLocalObjectReference{Kind: "StorageBucket", Name: "static"}}},
+			}}}},
+		},
+	}}
+
+	if got := ingressServices(ing); !slices.Equal(got, []string{"fallback", "api"}) {
+		t.Fatalf("unexpected backend services: %v", got)
+	}
+}
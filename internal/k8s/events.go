@@ -0,0 +1,113 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const DefaultEventLookback = time.Hour
+
+type EventResult struct {
+	Warnings []string
+	// Groups aggregates the warnings by reason and involved object, most
+	// recently seen first.
+	Groups []EventGroup
+}
+
+type EventGroup struct {
+	Reason    string
+	Kind      string
+	Namespace string
+	Name      string
+	// Message is the message of the most recent event.
+	Message  string
+	Count    int
+	LastSeen time.Time
+}
+
+// Object is the involved object as namespace/name, or just the name for
+// cluster-scoped objects such as nodes.
+func (g EventGroup) Object() string {
+	if g.Namespace == "" {
+		return g.Name
+	}
+	return g.Namespace + "/" + g.Name
+}
+
+func (c *Checker) checkEvents(ctx context.Context, namespace string) (*EventResult, error) {
+	events, err := c.client.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{
+		FieldSelector: "type=Warning",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &EventResult{}
+
+	cutoff := time.Now().Add(-c.opts.eventLookback())
+
+	groups := make(map[string]*EventGroup)
+	for _, event := range events.Items {
+		eventTime := eventLastSeen(event)
+		if !eventTime.After(cutoff) {
+			continue
+		}
+
+		msg := fmt.Sprintf("%s/%s: %s", event.InvolvedObject.Namespace, event.InvolvedObject.Name, event.Message)
+		result.Warnings = append(result.Warnings, msg)
+
+		obj := event.InvolvedObject
+		key := event.Reason + "|" + obj.Kind + "|" + obj.Namespace + "|" + obj.Name
+		group, ok := groups[key]
+		if !ok {
+			group = &EventGroup{Reason: event.Reason, Kind: obj.Kind, Namespace: obj.Namespace, Name: obj.Name}
+			groups[key] = group
+		}
+		group.Count += eventCount(event)
+		if eventTime.After(group.LastSeen) {
+			group.LastSeen = eventTime
+			group.Message = event.Message
+		}
+	}
+
+	for _, group := range groups {
+		result.Groups = append(result.Groups, *group)
+	}
+	sort.Slice(result.Groups, func(i, j int) bool {
+		a, b := result.Groups[i], result.Groups[j]
+		if !a.LastSeen.Equal(b.LastSeen) {
+			return a.LastSeen.After(b.LastSeen)
+		}
+		return a.Reason+a.Namespace+a.Name < b.Reason+b.Namespace+b.Name
+	})
+
+	return result, nil
+}
+
+// eventLastSeen handles both core/v1 events and events recorded through the
+// events.k8s.io API, which only set EventTime and Series.
+func eventLastSeen(event corev1.Event) time.Time {
+	if !event.LastTimestamp.IsZero() {
+		return event.LastTimestamp.Time
+	}
+	if event.Series != nil && !event.Series.LastObservedTime.IsZero() {
+		return event.Series.LastObservedTime.Time
+	}
+	return event.EventTime.Time
+}
+
+func eventCount(event corev1.Event) int {
+	switch {
+	case event.Count > 0:
+		return int(event.Count)
+	case event.Series != nil && event.Series.Count > 0:
+		return int(event.Series.Count)
+	default:
+		return 1
+	}
+}
@@ -0,0 +1,96 @@
+package k8s
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestCheckEvents_AggregatesByReasonAndObject(t *testing.T) {
+	now := time.Now()
+	event := func(name, reason, object, message string, count int32, lastSeen time.Time) *corev1.Event {
+		return &corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: name, Namespace: "web"},
+			InvolvedObject: corev1.ObjectReference{Kind: "Pod", Namespace: "web", Name: object},
+			Type:           corev1.EventTypeWarning,
+			Reason:         reason,
+			Message:        message,
+			Count:          count,
+			LastTimestamp:  metav1.NewTime(lastSeen),
+		}
+	}
+
+	client := fake.NewSimpleClientset(
+		event("ev1", "FailedMount", "db-0", "timed out", 3, now.Add(-40*time.Minute)),
+		event("ev2", "FailedMount", "db-0", "volume not attached", 2, now.Add(-10*time.Minute)),
+		event("ev3", "BackOff", "api", "Back-off restarting failed container", 7, now.Add(-5*time.Minute)),
+		event("ev4", "FailedMount", "api", "secret not found", 0, now.Add(-20*time.Minute)),
+		event("ev5", "BackOff", "old", "outside lookback", 1, now.Add(-50*time.Minute)),
+		// Events recorded through events.k8s.io only carry EventTime and Series.
+		&corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: "ev6", Namespace: "web"},
+			InvolvedObject: corev1.ObjectReference{Kind: "Node", Name: "node-1"},
+			Type:           corev1.EventTypeWarning,
+			Reason:         "Rebooted",
+			Message:        "node rebooted",
+			EventTime:      metav1.NewMicroTime(now.Add(-30 * time.Minute)),
+			Series:         &corev1.EventSeries{Count: 4, LastObservedTime: metav1.NewMicroTime(now.Add(-15 * time.Minute))},
+		},
+	)
+
+	checker := &Checker{client: client, opts: Options{EventLookback: 45 * time.Minute}}
+	result, err := checker.checkEvents(context.Background(), "web")
+	if err != nil {
+		t.Fatalf("checkEvents returned error: %v", err)
+	}
+
+	if len(result.Warnings) != 5 {
+		t.Fatalf("expected 5 warnings within the lookback, got %v", result.Warnings)
+	}
+
+	want := []struct {
+		reason string
+		object string
+		count  int
+		msg    string
+	}{
+		{"BackOff", "web/api", 7, "Back-off restarting failed container"},
+		{"FailedMount", "web/db-0", 5, "volume not attached"},
+		{"Rebooted", "node-1", 4, "node rebooted"},
+		{"FailedMount", "web/api", 1, "secret not found"},
+	}
+	if len(result.Groups) != len(want) {
+		t.Fatalf("expected %d groups, got %+v", len(want), result.Groups)
+	}
+	for i, w := range want {
+		got := result.Groups[i]
+		if got.Reason != w.reason || got.Object() != w.object || got.Count != w.count || got.Message != w.msg {
+			t.Errorf("group %d: expected %+v, got %+v", i, w, got)
+		}
+	}
+	if !result.Groups[1].LastSeen.Equal(now.Add(-10 * time.Minute)) {
+		t.Errorf("expected last seen of the latest event, got %v", result.Groups[1].LastSeen)
+	}
+}
+
+func TestCheckEvents_DefaultLookback(t *testing.T) {
+	client := fake.NewSimpleClientset(&corev1.Event{
+		ObjectMeta:     metav1.ObjectMeta{Name: "ev1", Namespace: "web"},
+		InvolvedObject: corev1.ObjectReference{Kind: "Pod", Namespace: "web", Name: "api"},
+		Type:           corev1.EventTypeWarning,
+		Reason:         "BackOff",
+		LastTimestamp:  metav1.NewTime(time.Now().Add(-2 * time.Hour)),
+	})
+
+	result, err := (&Checker{client: client}).checkEvents(context.Background(), "web")
+	if err != nil {
+		t.Fatalf("checkEvents returned error: %v", err)
+	}
+	if len(result.Groups) != 0 {
+		t.Fatalf("expected events older than %s to be ignored, got %+v", DefaultEventLookback, result.Groups)
+	}
+}
@@ -0,0 +1,182 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DefaultCronJobGrace caps how long after a scheduled run a CronJob may go
+// without a successful run before it is reported as missed. Schedules with a
+// shorter interval use their interval instead.
+const DefaultCronJobGrace = time.Hour
+
+type JobResult struct {
+	Total  int
+	Failed []FailedJob
+}
+
+type FailedJob struct {
+	Namespace string
+	Name      string
+	// Reason is the reason of the Failed condition, e.g.
+	// BackoffLimitExceeded or DeadlineExceeded.
+	Reason  string
+	Message string
+	// CronJob is the name of the owning CronJob, if any.
+	CronJob string
+}
+
+type CronJobResult struct {
+	Total      int
+	OnSchedule int
+	Missed     []MissedCronJob
+	Suspended  []string
+}
+
+type MissedCronJob struct {
+	Namespace string
+	Name      string
+	Schedule  string
+	// LastSuccess is zero if the CronJob never completed successfully.
+	LastSuccess time.Time
+	// ExpectedAt is the first scheduled run after LastSuccess, or after the
+	// last scheduled run if that failed.
+	ExpectedAt time.Time
+}
+
+func (c *Checker) checkJobs(ctx context.Context, namespace string) (*JobResult, *CronJobResult, error) {
+	cronJobs, err := c.client.BatchV1().CronJobs(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	jobs, err := c.client.BatchV1().Jobs(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	now := time.Now()
+	cronResult := &CronJobResult{
+		Total: len(cronJobs.Items),
+	}
+	lastSuccess := make(map[string]time.Time)
+
+	for _, cj := range cronJobs.Items {
+		cjName := fmt.Sprintf("%s/%s", cj.Namespace, cj.Name)
+		if cj.Status.LastSuccessfulTime != nil {
+			lastSuccess[cjName] = cj.Status.LastSuccessfulTime.Time
+		}
+
+		if cj.Spec.Suspend != nil && *cj.Spec.Suspend {
+			cronResult.Suspended = append(cronResult.Suspended, cjName)
+			continue
+		}
+		if missed, ok := missedCronJob(cj, now); ok {
+			cronResult.Missed = append(cronResult.Missed, missed)
+			continue
+		}
+		cronResult.OnSchedule++
+	}
+
+	jobResult := &JobResult{
+		Total: len(jobs.Items),
+	}
+
+	for _, job := range jobs.Items {
+		failed := jobFailedCondition(job)
+		if failed == nil {
+			continue
+		}
+
+		cronJob := ""
+		for _, owner := range job.OwnerReferences {
+			if owner.Kind == "CronJob" {
+				cronJob = owner.Name
+			}
+		}
+		// Failed jobs stay in the CronJob history after later runs
+		// succeeded again.
+		if cronJob != "" {
+			if success, ok := lastSuccess[job.Namespace+"/"+cronJob]; ok && failed.LastTransitionTime.Time.Before(success) {
+				continue
+			}
+		}
+
+		jobResult.Failed = append(jobResult.Failed, FailedJob{
+			Namespace: job.Namespace,
+			Name:      job.Name,
+			Reason:    failed.Reason,
+			Message:   failed.Message,
+			CronJob:   cronJob,
+		})
+	}
+
+	return jobResult, cronResult, nil
+}
+
+func jobFailedCondition(job batchv1.Job) *batchv1.JobCondition {
+	for i, condition := range job.Status.Conditions {
+		if condition.Type == batchv1.JobFailed && condition.Status == corev1.ConditionTrue {
+			return &job.Status.Conditions[i]
+		}
+	}
+	return nil
+}
+
+// missedCronJob reports a CronJob whose first scheduled run after its last
+// success (or its creation) is overdue. A run scheduled since then, whether
+// still active or failed, is not missed; failing runs are reported through
+// their Jobs, and only a later run that was never scheduled is missed.
+func missedCronJob(cj batchv1.CronJob, now time.Time) (MissedCronJob, bool) {
+	var location *time.Location
+	if cj.Spec.TimeZone != nil {
+		loc, err := time.LoadLocation(*cj.Spec.TimeZone)
+		if err != nil {
+			return MissedCronJob{}, false
+		}
+		location = loc
+	}
+	schedule, err := parseCron(cj.Spec.Schedule, location)
+	if err != nil {
+		return MissedCronJob{}, false
+	}
+
+	base := cj.CreationTimestamp.Time
+	var lastSuccess time.Time
+	if cj.Status.LastSuccessfulTime != nil {
+		lastSuccess = cj.Status.LastSuccessfulTime.Time
+		base = lastSuccess
+	}
+	if last := cj.Status.LastScheduleTime; last != nil && last.Time.After(base) {
+		if len(cj.Status.Active) > 0 {
+			return MissedCronJob{}, false
+		}
+		base = last.Time
+	}
+
+	expected := schedule.Next(base)
+	if expected.IsZero() {
+		return MissedCronJob{}, false
+	}
+
+	grace := DefaultCronJobGrace
+	if next := schedule.Next(expected); !next.IsZero() && next.Sub(expected) < grace {
+		grace = next.Sub(expected)
+	}
+	if now.Before(expected.Add(grace)) {
+		return MissedCronJob{}, false
+	}
+
+	return MissedCronJob{
+		Namespace:   cj.Namespace,
+		Name:        cj.Name,
+		Schedule:    cj.Spec.Schedule,
+		LastSuccess: lastSuccess,
+		ExpectedAt:  expected,
+	}, true
+}
@@ -0,0 +1,103 @@
+package k8s
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestCheckJobs(t *testing.T) {
+	now := time.Now()
+	at := func(d time.Duration) *metav1.Time {
+		ts := metav1.NewTime(now.Add(d))
+		return &ts
+	}
+	suspend := true
+
+	cronJob := func(name, schedule string, created time.Duration, lastSuccess *metav1.Time) *batchv1.CronJob {
+		return &batchv1.CronJob{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ops", CreationTimestamp: *at(created)},
+			Spec:       batchv1.CronJobSpec{Schedule: schedule},
+			Status:     batchv1.CronJobStatus{LastSuccessfulTime: lastSuccess},
+		}
+	}
+	failedJob := func(name, owner, reason string, failedAt time.Duration) *batchv1.Job {
+		job := &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ops"},
+			Status: batchv1.JobStatus{Conditions: []batchv1.JobCondition{{
+				Type:               batchv1.JobFailed,
+				Status:             corev1.ConditionTrue,
+				Reason:             reason,
+				LastTransitionTime: *at(failedAt),
+			}}},
+		}
+		if owner != "" {
+			job.OwnerReferences = []metav1.OwnerReference{{Kind: "CronJob", Name: owner}}
+		}
+		return job
+	}
+
+	running := cronJob("report", "*/5 * * * *", -time.Hour, at(-20*time.Minute))
+	running.Status.Active = []corev1.ObjectReference{{Name: "report-1"}}
+	running.Status.LastScheduleTime = at(-time.Minute)
+	lastRunFailed := cronJob("export", "@hourly", -72*time.Hour, at(-3*time.Hour))
+	lastRunFailed.Status.LastScheduleTime = at(-30 * time.Minute)
+	stalled := cronJob("rotate", "*/10 * * * *", -72*time.Hour, at(-3*time.Hour))
+	stalled.Status.LastScheduleTime = at(-time.Hour)
+	paused := cronJob("cleanup", "@daily", -72*time.Hour, nil)
+	paused.Spec.Suspend = &suspend
+
+	client := fake.NewSimpleClientset(
+		cronJob("backup", "@hourly", -72*time.Hour, at(-3*time.Hour)),
+		cronJob("sync", "*/5 * * * *", -time.Hour, at(-2*time.Minute)),
+		cronJob("never", "*/10 * * * *", -time.Hour, nil),
+		cronJob("fresh", "@daily", -time.Minute, nil),
+		running,
+		lastRunFailed,
+		stalled,
+		paused,
+		failedJob("backup-1", "backup", "BackoffLimitExceeded", -4*time.Hour),
+		failedJob("backup-2", "backup", "DeadlineExceeded", -2*time.Hour),
+		failedJob("export-1", "export", "BackoffLimitExceeded", -20*time.Minute),
+		failedJob("migrate", "", "BackoffLimitExceeded", -time.Hour),
+		&batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: "seed", Namespace: "ops"}},
+	)
+
+	jobs, cronJobs, err := (&Checker{client: client}).checkJobs(context.Background(), "ops")
+	if err != nil {
+		t.Fatalf("checkJobs returned error: %v", err)
+	}
+
+	if jobs.Total != 5 || len(jobs.Failed) != 3 {
+		t.Fatalf("expected 3 of 5 jobs failed, got %+v", jobs)
+	}
+	if jobs.Failed[0].Name != "backup-2" || jobs.Failed[0].Reason != "DeadlineExceeded" || jobs.Failed[0].CronJob != "backup" {
+		t.Fatalf("unexpected failed cronjob run: %+v", jobs.Failed[0])
+	}
+	if jobs.Failed[1].Name != "export-1" || jobs.Failed[1].CronJob != "export" {
+		t.Fatalf("expected the failed last run of export, got %+v", jobs.Failed[1])
+	}
+	if jobs.Failed[2].Name != "migrate" || jobs.Failed[2].CronJob != "" {
+		t.Fatalf("unexpected failed job: %+v", jobs.Failed[2])
+	}
+
+	if cronJobs.Total != 8 || cronJobs.OnSchedule != 4 {
+		t.Fatalf("unexpected cronjob counts: %+v", cronJobs)
+	}
+	// export failed its last run, which is reported as a failed job only;
+	// rotate has not been scheduled since its last run an hour ago.
+	if len(cronJobs.Missed) != 3 || cronJobs.Missed[0].Name != "backup" || cronJobs.Missed[1].Name != "never" || cronJobs.Missed[2].Name != "rotate" {
+		t.Fatalf("expected backup, never and rotate to be missed, got %+v", cronJobs.Missed)
+	}
+	if cronJobs.Missed[0].LastSuccess.IsZero() || !cronJobs.Missed[1].LastSuccess.IsZero() {
+		t.Fatalf("unexpected last success times: %+v", cronJobs.Missed)
+	}
+	if len(cronJobs.Suspended) != 1 || cronJobs.Suspended[0] != "ops/cleanup" {
+		t.Fatalf("unexpected suspended cronjobs: %v", cronJobs.Suspended)
+	}
+}
@@ -0,0 +1,253 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	DefaultRestartThreshold = 5
+	DefaultNotReadyAfter    = 5 * time.Minute
+	// TerminatingMargin is how long a pod may stay after its deletion
+	// timestamp, which already includes the grace period, before it is
+	// reported as stuck.
+	TerminatingMargin = 5 * time.Minute
+)
+
+type PodResult struct {
+	Total            int
+	Running          int
+	CrashLoopBackOff []string
+	ImagePullBackOff []string
+	Pending          []string
+	Failed           []string
+	// NotReady lists running pods that have not been ready for longer than
+	// Options.NotReadyAfter.
+	NotReady []PodIssue
+	// InitFailed lists pending pods whose init containers fail.
+	InitFailed []PodIssue
+	// Restarts and OOMKilled list pods with a container that terminated
+	// within the lookback window, either after at least
+	// Options.RestartThreshold restarts or because it ran out of memory.
+	// A pod appears in at most one of them.
+	Restarts  []PodIssue
+	OOMKilled []PodIssue
+	// Terminating lists pods stuck past their deletion timestamp plus
+	// TerminatingMargin, usually because their node is gone or a finalizer
+	// blocks.
+	Terminating []PodIssue
+	// Evicted groups evicted pods by the node they were evicted from. They
+	// are not listed in Failed.
+	Evicted []EvictedPods
+	// Unknown lists pods whose node stopped reporting their state.
+	Unknown []PodIssue
+}
+
+type EvictedPods struct {
+	Node string
+	Pods []string
+}
+
+// PodIssue describes a reported pod and, where relevant, the container that
+// caused it.
+type PodIssue struct {
+	Namespace string
+	Name      string
+	Container string
+	Node      string
+	// Reason is the waiting or termination reason of the container, e.g.
+	// OOMKilled, Error or CrashLoopBackOff.
+	Reason   string
+	ExitCode int32
+	Restarts int32
+	// Since is when the pod became not ready, the container last
+	// terminated or the pod should have been deleted.
+	Since time.Time
+}
+
+func (p PodIssue) Pod() string {
+	return p.Namespace + "/" + p.Name
+}
+
+func (c *Checker) checkPods(ctx context.Context, namespace string) (*PodResult, error) {
+	pods, err := c.client.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &PodResult{
+		Total: len(pods.Items),
+	}
+
+	now := time.Now()
+	notReadyAfter := c.opts.NotReadyAfter
+	if notReadyAfter <= 0 {
+		notReadyAfter = DefaultNotReadyAfter
+	}
+
+	evicted := make(map[string][]string)
+
+	for _, pod := range pods.Items {
+		podName := fmt.Sprintf("%s/%s", pod.Namespace, pod.Name)
+
+		if deletion := pod.DeletionTimestamp; deletion != nil && now.After(deletion.Add(TerminatingMargin)) {
+			result.Terminating = append(result.Terminating, PodIssue{
+				Namespace: pod.Namespace,
+				Name:      pod.Name,
+				Node:      pod.Spec.NodeName,
+				Reason:    "Terminating",
+				Since:     deletion.Time,
+			})
+			continue
+		}
+
+		switch pod.Status.Phase {
+		case corev1.PodRunning:
+			checkRunningPod(result, pod, now, notReadyAfter)
+		case corev1.PodPending:
+			if issue, ok := initContainerFailure(pod); ok {
+				result.InitFailed = append(result.InitFailed, issue)
+			} else {
+				result.Pending = append(result.Pending, podName)
+			}
+		case corev1.PodFailed:
+			if pod.Status.Reason == "Evicted" {
+				evicted[pod.Spec.NodeName] = append(evicted[pod.Spec.NodeName], podName)
+				continue
+			}
+			result.Failed = append(result.Failed, podName)
+		case corev1.PodUnknown:
+			result.Unknown = append(result.Unknown, PodIssue{
+				Namespace: pod.Namespace,
+				Name:      pod.Name,
+				Node:      pod.Spec.NodeName,
+				Reason:    pod.Status.Reason,
+			})
+		default:
+			result.Running++
+		}
+
+		c.checkContainerTerminations(result, pod, now)
+	}
+
+	for _, node := range slices.Sorted(maps.Keys(evicted)) {
+		result.Evicted = append(result.Evicted, EvictedPods{Node: node, Pods: evicted[node]})
+	}
+
+	return result, nil
+}
+
+func checkRunningPod(result *PodResult, pod corev1.Pod, now time.Time, notReadyAfter time.Duration) {
+	podName := fmt.Sprintf("%s/%s", pod.Namespace, pod.Name)
+
+	hasIssue := false
+	for _, cs := range pod.Status.ContainerStatuses {
+		if cs.State.Waiting != nil {
+			reason := cs.State.Waiting.Reason
+			switch reason {
+			case "CrashLoopBackOff":
+				result.CrashLoopBackOff = append(result.CrashLoopBackOff, podName)
+				hasIssue = true
+			case "ImagePullBackOff", "ErrImagePull":
+				result.ImagePullBackOff = append(result.ImagePullBackOff, podName)
+				hasIssue = true
+			}
+		}
+	}
+	if !hasIssue {
+		if since, ok := podNotReadySince(pod); ok && now.Sub(since) >= notReadyAfter {
+			result.NotReady = append(result.NotReady, PodIssue{Namespace: pod.Namespace, Name: pod.Name, Node: pod.Spec.NodeName, Reason: "NotReady", Since: since})
+			hasIssue = true
+		}
+	}
+	if !hasIssue {
+		result.Running++
+	}
+}
+
+func podNotReadySince(pod corev1.Pod) (time.Time, bool) {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.LastTransitionTime.Time, condition.Status != corev1.ConditionTrue
+		}
+	}
+	return time.Time{}, false
+}
+
+// initContainerFailure returns the first init container that is crash
+// looping, cannot pull its image or exited with an error.
+func initContainerFailure(pod corev1.Pod) (PodIssue, bool) {
+	for _, cs := range pod.Status.InitContainerStatuses {
+		issue := PodIssue{Namespace: pod.Namespace, Name: pod.Name, Container: cs.Name, Restarts: cs.RestartCount}
+		if waiting := cs.State.Waiting; waiting != nil {
+			switch waiting.Reason {
+			case "CrashLoopBackOff", "ImagePullBackOff", "ErrImagePull", "CreateContainerConfigError":
+				issue.Reason = waiting.Reason
+				if last := cs.LastTerminationState.Terminated; last != nil {
+					issue.ExitCode = last.ExitCode
+					issue.Since = last.FinishedAt.Time
+				}
+				return issue, true
+			}
+		}
+		if terminated := cs.State.Terminated; terminated != nil && terminated.ExitCode != 0 {
+			issue.Reason = terminated.Reason
+			issue.ExitCode = terminated.ExitCode
+			issue.Since = terminated.FinishedAt.Time
+			return issue, true
+		}
+	}
+	return PodIssue{}, false
+}
+
+func (c *Checker) checkContainerTerminations(result *PodResult, pod corev1.Pod, now time.Time) {
+	threshold := int32(c.opts.RestartThreshold)
+	if threshold <= 0 {
+		threshold = DefaultRestartThreshold
+	}
+	cutoff := now.Add(-c.opts.eventLookback())
+
+	var oom, restarts *PodIssue
+	for _, cs := range pod.Status.ContainerStatuses {
+		terminated := lastTermination(cs)
+		if terminated == nil || !terminated.FinishedAt.After(cutoff) {
+			continue
+		}
+
+		issue := PodIssue{
+			Namespace: pod.Namespace,
+			Name:      pod.Name,
+			Container: cs.Name,
+			Reason:    terminated.Reason,
+			ExitCode:  terminated.ExitCode,
+			Restarts:  cs.RestartCount,
+			Since:     terminated.FinishedAt.Time,
+		}
+		if terminated.Reason == "OOMKilled" && (oom == nil || issue.Since.After(oom.Since)) {
+			oom = &issue
+		}
+		if cs.RestartCount >= threshold && (restarts == nil || cs.RestartCount > restarts.Restarts) {
+			restarts = &issue
+		}
+	}
+
+	switch {
+	case oom != nil:
+		result.OOMKilled = append(result.OOMKilled, *oom)
+	case restarts != nil:
+		result.Restarts = append(result.Restarts, *restarts)
+	}
+}
+
+func lastTermination(cs corev1.ContainerStatus) *corev1.ContainerStateTerminated {
+	if cs.State.Terminated != nil {
+		return cs.State.Terminated
+	}
+	return cs.LastTerminationState.Terminated
+}
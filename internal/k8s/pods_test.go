@@ -0,0 +1,155 @@
+package k8s
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestCheckPods_ContainerCategories(t *testing.T) {
+	now := time.Now()
+	ago := func(d time.Duration) metav1.Time { return metav1.NewTime(now.Add(-d)) }
+
+	runningPod := func(name string, ready bool, readySince time.Duration, containers ...corev1.ContainerStatus) *corev1.Pod {
+		status := corev1.ConditionTrue
+		if !ready {
+			status = corev1.ConditionFalse
+		}
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "web"},
+			Status: corev1.PodStatus{
+				Phase:             corev1.PodRunning,
+				Conditions:        []corev1.PodCondition{{Type: corev1.PodReady, Status: status, LastTransitionTime: ago(readySince)}},
+				ContainerStatuses: containers,
+			},
+		}
+	}
+	restarted := func(name string, restarts int32, reason string, exitCode int32, finished time.Duration) corev1.ContainerStatus {
+		return corev1.ContainerStatus{
+			Name:         name,
+			RestartCount: restarts,
+			State:        corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+			LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+				Reason: reason, ExitCode: exitCode, FinishedAt: ago(finished),
+			}},
+		}
+	}
+
+	client := fake.NewSimpleClientset(
+		runningPod("healthy", true, time.Hour, restarted("app", 1, "Error", 1, 10*time.Minute)),
+		runningPod("starting", false, time.Minute),
+		runningPod("unready", false, 20*time.Minute),
+		runningPod("flapping", true, time.Hour,
+			restarted("app", 7, "Error", 2, 5*time.Minute),
+			restarted("sidecar", 12, "Completed", 0, 30*time.Minute)),
+		runningPod("old-restarts", true, time.Hour, restarted("app", 40, "Error", 1, 3*time.Hour)),
+		runningPod("oom", true, time.Hour,
+			restarted("app", 9, "Error", 1, 5*time.Minute),
+			restarted("worker", 1, "OOMKilled", 137, 15*time.Minute)),
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "init", Namespace: "web"},
+			Status: corev1.PodStatus{
+				Phase: corev1.PodPending,
+				InitContainerStatuses: []corev1.ContainerStatus{
+					{Name: "wait", State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 0}}},
+					{
+						Name:                 "migrate",
+						RestartCount:         3,
+						State:                corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+						LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Reason: "Error", ExitCode: 1}},
+					},
+				},
+			},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "scheduling", Namespace: "web"},
+			Status:     corev1.PodStatus{Phase: corev1.PodPending},
+		},
+	)
+
+	checker := &Checker{client: client, opts: Options{RestartThreshold: 5, NotReadyAfter: 10 * time.Minute}}
+	result, err := checker.checkPods(context.Background(), "web")
+	if err != nil {
+		t.Fatalf("checkPods returned error: %v", err)
+	}
+
+	if result.Total != 8 || result.Running != 5 {
+		t.Fatalf("unexpected pod counts: total %d, running %d", result.Total, result.Running)
+	}
+	if len(result.NotReady) != 1 || result.NotReady[0].Pod() != "web/unready" {
+		t.Fatalf("unexpected not ready pods: %+v", result.NotReady)
+	}
+	if len(result.InitFailed) != 1 || result.InitFailed[0].Container != "migrate" || result.InitFailed[0].Reason != "CrashLoopBackOff" || result.InitFailed[0].ExitCode != 1 {
+		t.Fatalf("unexpected init failures: %+v", result.InitFailed)
+	}
+	if len(result.Pending) != 1 || result.Pending[0] != "web/scheduling" {
+		t.Fatalf("expected init failures to be excluded from pending, got %v", result.Pending)
+	}
+	if len(result.Restarts) != 1 || result.Restarts[0].Pod() != "web/flapping" || result.Restarts[0].Container != "sidecar" || result.Restarts[0].Restarts != 12 {
+		t.Fatalf("unexpected restarts: %+v", result.Restarts)
+	}
+	if len(result.OOMKilled) != 1 || result.OOMKilled[0].Pod() != "web/oom" || result.OOMKilled[0].Container != "worker" || result.OOMKilled[0].ExitCode != 137 {
+		t.Fatalf("unexpected OOMKilled pods: %+v", result.OOMKilled)
+	}
+}
+
+func TestCheckPods_TerminatingEvictedUnknown(t *testing.T) {
+	now := time.Now()
+	deletedAt := func(d time.Duration) *metav1.Time {
+		ts := metav1.NewTime(now.Add(-d))
+		return &ts
+	}
+	pod := func(name, node string, phase corev1.PodPhase, reason string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "web"},
+			Spec:       corev1.PodSpec{NodeName: node},
+			Status:     corev1.PodStatus{Phase: phase, Reason: reason},
+		}
+	}
+
+	stuck := pod("stuck", "node-1", corev1.PodRunning, "")
+	stuck.DeletionTimestamp = deletedAt(20 * time.Minute)
+	stopping := pod("stopping", "node-1", corev1.PodRunning, "")
+	stopping.DeletionTimestamp = deletedAt(time.Minute)
+
+	client := fake.NewSimpleClientset(
+		stuck,
+		stopping,
+		pod("evicted-a", "node-2", corev1.PodFailed, "Evicted"),
+		pod("evicted-b", "node-1", corev1.PodFailed, "Evicted"),
+		pod("evicted-c", "node-2", corev1.PodFailed, "Evicted"),
+		pod("crashed", "node-1", corev1.PodFailed, "Error"),
+		pod("lost", "node-3", corev1.PodUnknown, "NodeLost"),
+	)
+
+	result, err := (&Checker{client: client}).checkPods(context.Background(), "web")
+	if err != nil {
+		t.Fatalf("checkPods returned error: %v", err)
+	}
+
+	if result.Running != 1 {
+		t.Fatalf("expected only the pod within its grace period to count as running, got %d", result.Running)
+	}
+	if len(result.Terminating) != 1 || result.Terminating[0].Pod() != "web/stuck" || result.Terminating[0].Node != "node-1" {
+		t.Fatalf("unexpected terminating pods: %+v", result.Terminating)
+	}
+	if len(result.Failed) != 1 || result.Failed[0] != "web/crashed" {
+		t.Fatalf("expected evicted pods to be excluded from failed, got %v", result.Failed)
+	}
+	if len(result.Evicted) != 2 {
+		t.Fatalf("expected evicted pods grouped by 2 nodes, got %+v", result.Evicted)
+	}
+	if result.Evicted[0].Node != "node-1" || len(result.Evicted[0].Pods) != 1 {
+		t.Fatalf("unexpected evicted group: %+v", result.Evicted[0])
+	}
+	if result.Evicted[1].Node != "node-2" || len(result.Evicted[1].Pods) != 2 {
+		t.Fatalf("unexpected evicted group: %+v", result.Evicted[1])
+	}
+	if len(result.Unknown) != 1 || result.Unknown[0].Node != "node-3" || result.Unknown[0].Reason != "NodeLost" {
+		t.Fatalf("unexpected unknown pods: %+v", result.Unknown)
+	}
+}
@@ -0,0 +1,129 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RolloutStuckAfter is how long a StatefulSet rolling update may take before
+// it is reported as stuck, matching the default progress deadline of
+// Deployments.
+const RolloutStuckAfter = 10 * time.Minute
+
+type StatefulSetResult struct {
+	Total       int
+	Ready       int
+	Unavailable []string
+	// RolloutStuck lists StatefulSets that are not ready while a rolling
+	// update to a new revision started more than RolloutStuckAfter ago.
+	// Younger rolling updates are not reported.
+	RolloutStuck []string
+}
+
+type DaemonSetResult struct {
+	Total       int
+	Ready       int
+	Unavailable []string
+	// Misscheduled lists DaemonSets running pods on nodes they should not
+	// run on, e.g. after a node selector or taint change.
+	Misscheduled []string
+}
+
+func (c *Checker) checkStatefulSets(ctx context.Context, namespace string) (*StatefulSetResult, error) {
+	statefulSets, err := c.client.AppsV1().StatefulSets(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &StatefulSetResult{
+		Total: len(statefulSets.Items),
+	}
+
+	now := time.Now()
+	for _, sts := range statefulSets.Items {
+		stsName := fmt.Sprintf("%s/%s", sts.Namespace, sts.Name)
+
+		replicas := int32(1)
+		if sts.Spec.Replicas != nil {
+			replicas = *sts.Spec.Replicas
+		}
+
+		switch {
+		case sts.Status.ReadyReplicas >= replicas:
+			result.Ready++
+		case statefulSetRolling(sts, replicas):
+			started, err := c.rolloutStarted(ctx, sts)
+			switch {
+			case err != nil:
+				// Without the start of the rollout, report the
+				// StatefulSet like any other that is not ready.
+				result.Unavailable = append(result.Unavailable, stsName)
+			case now.Sub(started) >= RolloutStuckAfter:
+				result.RolloutStuck = append(result.RolloutStuck, stsName)
+			}
+		default:
+			result.Unavailable = append(result.Unavailable, stsName)
+		}
+	}
+
+	return result, nil
+}
+
+// statefulSetRolling reports whether a rolling update has not reached all
+// pods it should update. Pods below a partition are left at the old revision
+// on purpose and OnDelete updates only happen when pods are deleted.
+func statefulSetRolling(sts appsv1.StatefulSet, replicas int32) bool {
+	if sts.Spec.UpdateStrategy.Type == appsv1.OnDeleteStatefulSetStrategyType {
+		return false
+	}
+	if sts.Status.UpdateRevision == "" || sts.Status.CurrentRevision == sts.Status.UpdateRevision {
+		return false
+	}
+
+	partition := int32(0)
+	if ru := sts.Spec.UpdateStrategy.RollingUpdate; ru != nil && ru.Partition != nil {
+		partition = *ru.Partition
+	}
+	return sts.Status.UpdatedReplicas < replicas-partition
+}
+
+// rolloutStarted returns when the revision a StatefulSet is rolling out to was
+// created, i.e. when its rolling update started.
+func (c *Checker) rolloutStarted(ctx context.Context, sts appsv1.StatefulSet) (time.Time, error) {
+	revision, err := c.client.AppsV1().ControllerRevisions(sts.Namespace).Get(ctx, sts.Status.UpdateRevision, metav1.GetOptions{})
+	if err != nil {
+		return time.Time{}, err
+	}
+	return revision.CreationTimestamp.Time, nil
+}
+
+func (c *Checker) checkDaemonSets(ctx context.Context, namespace string) (*DaemonSetResult, error) {
+	daemonSets, err := c.client.AppsV1().DaemonSets(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &DaemonSetResult{
+		Total: len(daemonSets.Items),
+	}
+
+	for _, ds := range daemonSets.Items {
+		dsName := fmt.Sprintf("%s/%s", ds.Namespace, ds.Name)
+
+		switch {
+		case ds.Status.NumberReady < ds.Status.DesiredNumberScheduled:
+			result.Unavailable = append(result.Unavailable, dsName)
+		case ds.Status.NumberMisscheduled > 0:
+			result.Misscheduled = append(result.Misscheduled, dsName)
+			result.Ready++
+		default:
+			result.Ready++
+		}
+	}
+
+	return result, nil
+}
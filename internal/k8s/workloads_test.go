@@ -0,0 +1,103 @@
+package k8s
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func int32Ptr(i int32) *int32 { return &i }
+
+func TestCheckStatefulSets(t *testing.T) {
+	statefulSet := func(name string, replicas, ready, updated int32, current, update string) *appsv1.StatefulSet {
+		return &appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "db"},
+			Spec:       appsv1.StatefulSetSpec{Replicas: int32Ptr(replicas)},
+			Status: appsv1.StatefulSetStatus{
+				ReadyReplicas:   ready,
+				UpdatedReplicas: updated,
+				CurrentRevision: current,
+				UpdateRevision:  update,
+			},
+		}
+	}
+
+	partitioned := statefulSet("canary", 3, 2, 1, "rev-1", "rev-2")
+	partitioned.Spec.UpdateStrategy.RollingUpdate = &appsv1.RollingUpdateStatefulSetStrategy{Partition: int32Ptr(2)}
+	onDelete := statefulSet("manual", 2, 1, 0, "rev-1", "rev-2")
+	onDelete.Spec.UpdateStrategy.Type = appsv1.OnDeleteStatefulSetStrategyType
+
+	revision := func(name string, age time.Duration) *appsv1.ControllerRevision {
+		return &appsv1.ControllerRevision{ObjectMeta: metav1.ObjectMeta{
+			Name: name, Namespace: "db", CreationTimestamp: metav1.NewTime(time.Now().Add(-age)),
+		}}
+	}
+
+	client := fake.NewSimpleClientset(
+		statefulSet("postgres", 3, 3, 3, "rev-1", "rev-1"),
+		statefulSet("redis", 3, 1, 3, "rev-1", "rev-1"),
+		statefulSet("kafka", 3, 2, 1, "kafka-1", "kafka-2"),
+		statefulSet("zookeeper", 3, 2, 1, "zookeeper-1", "zookeeper-2"),
+		statefulSet("etcd", 3, 2, 1, "etcd-1", "etcd-2"),
+		revision("kafka-2", time.Hour),
+		revision("zookeeper-2", time.Minute),
+		partitioned,
+		onDelete,
+	)
+
+	result, err := (&Checker{client: client}).checkStatefulSets(context.Background(), "db")
+	if err != nil {
+		t.Fatalf("checkStatefulSets returned error: %v", err)
+	}
+
+	// zookeeper is still within RolloutStuckAfter and not reported; etcd
+	// has no revision to date its rollout.
+	if result.Total != 7 || result.Ready != 1 || len(result.Unavailable) != 4 {
+		t.Fatalf("unexpected statefulset counts: %+v", result)
+	}
+	if len(result.RolloutStuck) != 1 || result.RolloutStuck[0] != "db/kafka" {
+		t.Fatalf("expected only db/kafka to be stuck, got %v", result.RolloutStuck)
+	}
+	assertContains(t, result.Unavailable, "db/redis")
+	assertContains(t, result.Unavailable, "db/canary")
+	assertContains(t, result.Unavailable, "db/manual")
+	assertContains(t, result.Unavailable, "db/etcd")
+}
+
+func TestCheckDaemonSets(t *testing.T) {
+	daemonSet := func(name string, desired, ready, misscheduled int32) *appsv1.DaemonSet {
+		return &appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "logging"},
+			Status: appsv1.DaemonSetStatus{
+				DesiredNumberScheduled: desired,
+				NumberReady:            ready,
+				NumberMisscheduled:     misscheduled,
+			},
+		}
+	}
+
+	client := fake.NewSimpleClientset(
+		daemonSet("fluent-bit", 3, 3, 0),
+		daemonSet("node-exporter", 3, 2, 0),
+		daemonSet("promtail", 3, 3, 1),
+	)
+
+	result, err := (&Checker{client: client}).checkDaemonSets(context.Background(), "logging")
+	if err != nil {
+		t.Fatalf("checkDaemonSets returned error: %v", err)
+	}
+
+	if result.Total != 3 || result.Ready != 2 {
+		t.Fatalf("unexpected daemonset counts: %+v", result)
+	}
+	if len(result.Unavailable) != 1 || result.Unavailable[0] != "logging/node-exporter" {
+		t.Fatalf("unexpected unavailable daemonsets: %v", result.Unavailable)
+	}
+	if len(result.Misscheduled) != 1 || result.Misscheduled[0] != "logging/promtail" {
+		t.Fatalf("unexpected misscheduled daemonsets: %v", result.Misscheduled)
+	}
+}
@@ -0,0 +1,156 @@
+package notify
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/peterpisarcik/ionos-cloud-watchdog/internal/output"
+)
+
+const alertmanagerAlertName = "IONOSCloudWatchdogIssue"
+
+// alertmanagerRunsToExpire is the number of runs a firing alert outlives
+// without being re-sent, so that a late run does not resolve it.
+const alertmanagerRunsToExpire = 3
+
+// AlertmanagerNotifier pushes issues to the Alertmanager v2 API. Alertmanager
+// expires alerts that are not re-sent, so active issues are pushed after every
+// run and resolved issues are sent once with endsAt set.
+type AlertmanagerNotifier struct {
+	URL     string
+	Labels  map[string]string
+	Headers map[string]string
+	Client  *http.Client
+	// Interval is the time between runs. Firing alerts end a few intervals
+	// after the run that sent them; without it Alertmanager resolves them
+	// after its resolve_timeout.
+	Interval time.Duration
+
+	// severities remembers the severity label each active issue was last
+	// pushed with, so the old alert can be resolved when it changes.
+	mu         sync.Mutex
+	severities map[string]output.Severity
+}
+
+type alertmanagerAlert struct {
+	Labels       map[string]string `json:"labels"`
+	Annotations  map[string]string `json:"annotations,omitempty"`
+	StartsAt     time.Time         `json:"startsAt"`
+	EndsAt       *time.Time        `json:"endsAt,omitempty"`
+	GeneratorURL string            `json:"generatorURL,omitempty"`
+}
+
+func (a *AlertmanagerNotifier) Name() string {
+	return "alertmanager"
+}
+
+func (a *AlertmanagerNotifier) notifyEveryRun() bool {
+	return true
+}
+
+func (a *AlertmanagerNotifier) Notify(ctx context.Context, event Event) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.severities == nil {
+		a.severities = make(map[string]output.Severity)
+	}
+
+	endsAt := event.Time
+	var expiresAt *time.Time
+	if a.Interval > 0 {
+		expires := event.Time.Add(alertmanagerRunsToExpire * a.Interval)
+		expiresAt = &expires
+	}
+	alerts := make([]alertmanagerAlert, 0, len(event.Active)+len(event.Resolved))
+	for _, issue := range event.Active {
+		// The severity is a label, so a changed severity is a new alert in
+		// Alertmanager and the one with the old severity has to be resolved.
+		key := issue.Key()
+		if previous, ok := a.severities[key]; ok && previous != issue.Severity {
+			old := issue
+			old.Severity = previous
+			alerts = append(alerts, a.alert(old, event.Time, &endsAt))
+		}
+		a.severities[key] = issue.Severity
+		alerts = append(alerts, a.alert(issue, event.Time, expiresAt))
+	}
+	for _, issue := range event.Resolved {
+		delete(a.severities, issue.Key())
+		alerts = append(alerts, a.alert(issue, event.Time, &endsAt))
+	}
+	if len(alerts) == 0 {
+		return nil
+	}
+	return postJSON(ctx, a.Client, alertmanagerEndpoint(a.URL), a.Headers, alerts)
+}
+
+// alert builds an alert for an issue. Alertmanager keeps the earliest
+// startsAt of an alert, so sending the run time on every push is safe.
+func (a *AlertmanagerNotifier) alert(issue output.Issue, now time.Time, endsAt *time.Time) alertmanagerAlert {
+	labels := make(map[string]string, len(a.Labels)+8)
+	for k, v := range a.Labels {
+		labels[k] = v
+	}
+	for k, v := range alertLabels(issue) {
+		labels[k] = v
+	}
+
+	annotations := map[string]string{"summary": issue.Message}
+	if issue.Hint != "" {
+		annotations["description"] = issue.Hint
+	}
+
+	return alertmanagerAlert{
+		Labels:      labels,
+		Annotations: annotations,
+		StartsAt:    now,
+		EndsAt:      endsAt,
+	}
+}
+
+// alertLabels identifies an issue. The dedup_key label is derived from
+// Issue.Key and keeps resources apart whose other labels are equal, such as
+// servers with the same name in different datacenters. Issues without a
+// resource are keyed by their message.
+func alertLabels(issue output.Issue) map[string]string {
+	labels := map[string]string{
+		"alertname": alertmanagerAlertName,
+		"check":     issue.Check,
+		"subsystem": issue.Subsystem,
+		"severity":  string(issue.Severity),
+		"dedup_key": DedupKey(issue),
+	}
+	switch issue.Resource.Kind {
+	case "Datacenter":
+		labels["datacenter"] = issue.Resource.Name
+	case "K8sCluster":
+		labels["cluster"] = issue.Resource.Name
+	}
+	if label := parentLabel(issue.Resource.Parent); label != "" {
+		labels[label] = issue.Resource.Parent.Name
+	}
+	if issue.Resource.Kind != "" {
+		labels["kind"] = issue.Resource.Kind
+	}
+	if issue.Resource.Namespace != "" {
+		labels["namespace"] = issue.Resource.Namespace
+	}
+	if issue.Resource.Name != "" {
+		labels["name"] = issue.Resource.Name
+	}
+	if issue.Reason != "" {
+		labels["reason"] = issue.Reason
+	}
+	return labels
+}
+
+func alertmanagerEndpoint(base string) string {
+	base = strings.TrimSuffix(base, "/")
+	if strings.HasSuffix(base, "/api/v2/alerts") {
+		return base
+	}
+	return base + "/api/v2/alerts"
+}
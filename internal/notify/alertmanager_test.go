@@ -0,0 +1,130 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/peterpisarcik/ionos-cloud-watchdog/internal/output"
+)
+
+func TestAlertmanagerNotifier_PostsFiringAndResolvedAlerts(t *testing.T) {
+	var path string
+	var alerts []alertmanagerAlert
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		if err := json.NewDecoder(r.Body).Decode(&alerts); err != nil {
+			t.Errorf("invalid payload: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	dcIssue := output.Issue{
+		Severity:  output.SeverityCritical,
+		Check:     output.CheckDatacenters,
+		Subsystem: output.SubsystemIONOS,
+		Resource:  output.Resource{Kind: "Datacenter", Name: "prod", ID: "dc-1"},
+		Message:   "Datacenter prod state: FAILED",
+		Hint:      "Check the datacenter in the DCD",
+	}
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	event := Event{Status: "CRITICAL", Active: []output.Issue{dcIssue}, Resolved: []output.Issue{podIssue}, Time: now}
+
+	notifier := &AlertmanagerNotifier{URL: server.URL + "/", Labels: map[string]string{"env": "prod"}, Interval: time.Minute}
+	if err := notifier.Notify(context.Background(), event); err != nil {
+		t.Fatalf("Notify returned error: %v", err)
+	}
+
+	if path != "/api/v2/alerts" {
+		t.Fatalf("unexpected path %q", path)
+	}
+	if len(alerts) != 2 {
+		t.Fatalf("expected 2 alerts, got %d", len(alerts))
+	}
+
+	firing := alerts[0]
+	if firing.Labels["datacenter"] != "prod" || firing.Labels["severity"] != "critical" || firing.Labels["check"] != output.CheckDatacenters || firing.Labels["env"] != "prod" {
+		t.Fatalf("unexpected firing labels: %v", firing.Labels)
+	}
+	if firing.Annotations["summary"] != dcIssue.Message || firing.Annotations["description"] != dcIssue.Hint {
+		t.Fatalf("unexpected annotations: %v", firing.Annotations)
+	}
+	if expires := now.Add(3 * time.Minute); firing.EndsAt == nil || !firing.EndsAt.Equal(expires) {
+		t.Fatalf("expected firing alert to end at %v unless re-sent, got %v", expires, firing.EndsAt)
+	}
+
+	resolved := alerts[1]
+	if resolved.Labels["namespace"] != "ns" || resolved.Labels["name"] != "a" || resolved.Labels["dedup_key"] != DedupKey(podIssue) {
+		t.Fatalf("unexpected resolved labels: %v", resolved.Labels)
+	}
+	if resolved.EndsAt == nil || !resolved.EndsAt.Equal(now) {
+		t.Fatalf("expected resolved alert to end at %v, got %v", now, resolved.EndsAt)
+	}
+}
+
+func TestAlertmanagerNotifier_ResentOnUnchangedRuns(t *testing.T) {
+	server, requests := newCaptureServer(t, http.StatusOK)
+
+	recorder := &recordingNotifier{}
+	d := NewDispatcher(0, recorder, &AlertmanagerNotifier{URL: server.URL})
+
+	dispatch(t, d, "OK")
+	dispatch(t, d, "CRITICAL", authIssue)
+	dispatch(t, d, "CRITICAL", authIssue)
+
+	if len(recorder.events) != 1 {
+		t.Fatalf("expected other notifiers to be notified once, got %d", len(recorder.events))
+	}
+	if len(*requests) != 2 {
+		t.Fatalf("expected the firing alert to be pushed on every run, got %d requests", len(*requests))
+	}
+}
+
+func TestAlertmanagerNotifier_ResolvesOldSeverity(t *testing.T) {
+	server, requests := newCaptureServer(t, http.StatusOK)
+	d := NewDispatcher(0, &AlertmanagerNotifier{URL: server.URL})
+
+	escalated := podIssue
+	escalated.Severity = output.SeverityCritical
+	dispatch(t, d, "WARNING", podIssue)
+	dispatch(t, d, "CRITICAL", escalated)
+	dispatch(t, d, "CRITICAL", escalated)
+
+	if len(*requests) != 3 {
+		t.Fatalf("expected 3 pushes, got %d", len(*requests))
+	}
+	var alerts []alertmanagerAlert
+	if err := json.Unmarshal((*requests)[1].body, &alerts); err != nil {
+		t.Fatalf("invalid payload: %v", err)
+	}
+	if len(alerts) != 2 {
+		t.Fatalf("expected the warning alert to be resolved next to the critical one, got %+v", alerts)
+	}
+	if alerts[0].Labels["severity"] != "warning" || alerts[0].EndsAt == nil {
+		t.Fatalf("expected the warning alert to be resolved, got %+v", alerts[0])
+	}
+	if alerts[1].Labels["severity"] != "critical" || alerts[1].EndsAt != nil {
+		t.Fatalf("expected a firing critical alert, got %+v", alerts[1])
+	}
+
+	var next []alertmanagerAlert
+	if err := json.Unmarshal((*requests)[2].body, &next); err != nil {
+		t.Fatalf("invalid payload: %v", err)
+	}
+	if len(next) != 1 || next[0].EndsAt != nil {
+		t.Fatalf("expected only the critical alert on the next run, got %+v", next)
+	}
+}
+
+func TestAlertLabels_ServersInOneDatacenter(t *testing.T) {
+	web1, web2 := alertLabels(serverIssue("srv-1", "web")), alertLabels(serverIssue("srv-2", "web"))
+	if web1["datacenter"] != "prod" || web1["kind"] != "Server" || web1["name"] != "web" {
+		t.Fatalf("unexpected labels: %v", web1)
+	}
+	if web1["dedup_key"] == web2["dedup_key"] {
+		t.Fatalf("expected equally named servers to have different dedup keys")
+	}
+}
@@ -0,0 +1,102 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/peterpisarcik/ionos-cloud-watchdog/internal/output"
+)
+
+// maxListedIssues keeps chat messages readable when a run reports many issues.
+const maxListedIssues = 20
+
+type SlackNotifier struct {
+	WebhookURL string
+	Client     *http.Client
+}
+
+func (s *SlackNotifier) Name() string {
+	return "slack"
+}
+
+func (s *SlackNotifier) Notify(ctx context.Context, event Event) error {
+	text := "*" + title(event) + "*"
+	if lines := issueLines(event); len(lines) > 0 {
+		text += "\n" + strings.Join(lines, "\n")
+	}
+	return postJSON(ctx, s.Client, s.WebhookURL, nil, map[string]string{"text": text})
+}
+
+type TeamsNotifier struct {
+	WebhookURL string
+	Client     *http.Client
+}
+
+func (t *TeamsNotifier) Name() string {
+	return "teams"
+}
+
+func (t *TeamsNotifier) Notify(ctx context.Context, event Event) error {
+	card := map[string]string{
+		"@type":      "MessageCard",
+		"@context":   "https://schema.org/extensions",
+		"summary":    title(event),
+		"title":      title(event),
+		"themeColor": themeColor(event.Status),
+		"text":       strings.Join(issueLines(event), "\n\n"),
+	}
+	return postJSON(ctx, t.Client, t.WebhookURL, nil, card)
+}
+
+func title(event Event) string {
+	switch event.Kind() {
+	case EventReminder:
+		return fmt.Sprintf("IONOS Cloud Watchdog: still %s (%d active issues)", event.Status, len(event.Active))
+	case EventStatusChanged:
+		return fmt.Sprintf("IONOS Cloud Watchdog: %s (was %s)", event.Status, event.PreviousStatus)
+	default:
+		return fmt.Sprintf("IONOS Cloud Watchdog: %s, issues changed", event.Status)
+	}
+}
+
+func issueLines(event Event) []string {
+	var lines []string
+	if event.Reminder {
+		for _, issue := range event.Active {
+			lines = append(lines, "- "+issueText(issue))
+		}
+	} else {
+		for _, issue := range event.Opened {
+			lines = append(lines, "- New: "+issueText(issue))
+		}
+		for _, issue := range event.Updated {
+			lines = append(lines, "- Changed: "+issueText(issue))
+		}
+		for _, issue := range event.Resolved {
+			lines = append(lines, "- Resolved: "+issue.Message)
+		}
+	}
+
+	if len(lines) > maxListedIssues {
+		more := len(lines) - maxListedIssues
+		lines = append(lines[:maxListedIssues], fmt.Sprintf("- ... and %d more", more))
+	}
+	return lines
+}
+
+func issueText(issue output.Issue) string {
+	return fmt.Sprintf("[%s] %s", strings.ToUpper(string(issue.Severity)), issue.Message)
+}
+
+func themeColor(status string) string {
+	switch status {
+	case "CRITICAL":
+		return "E01E5A"
+	case "WARNING":
+		return "ECB22E"
+	default:
+		return "2EB67D"
+	}
+}
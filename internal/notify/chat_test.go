@@ -0,0 +1,126 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/peterpisarcik/ionos-cloud-watchdog/internal/output"
+)
+
+func TestSlackNotifier_PostsText(t *testing.T) {
+	server, requests := newCaptureServer(t, http.StatusOK)
+
+	notifier := &SlackNotifier{WebhookURL: server.URL}
+	if err := notifier.Notify(context.Background(), testEvent()); err != nil {
+		t.Fatalf("Notify returned error: %v", err)
+	}
+
+	var payload map[string]string
+	if err := json.Unmarshal((*requests)[0].body, &payload); err != nil {
+		t.Fatalf("invalid payload: %v", err)
+	}
+	text := payload["text"]
+	if !strings.Contains(text, "IONOS Cloud Watchdog: CRITICAL (was OK)") || !strings.Contains(text, "New: [CRITICAL] IONOS authentication failed") {
+		t.Fatalf("unexpected slack text: %s", text)
+	}
+}
+
+func TestSlackNotifier_ListsIssueChanges(t *testing.T) {
+	server, requests := newCaptureServer(t, http.StatusOK)
+
+	escalated := podIssue
+	escalated.Severity = output.SeverityCritical
+	event := Event{
+		Status:         "CRITICAL",
+		PreviousStatus: "CRITICAL",
+		Opened:         []output.Issue{authIssue},
+		Updated:        []output.Issue{escalated},
+		Resolved:       []output.Issue{serverIssue("srv-1", "web-1")},
+	}
+	notifier := &SlackNotifier{WebhookURL: server.URL}
+	if err := notifier.Notify(context.Background(), event); err != nil {
+		t.Fatalf("Notify returned error: %v", err)
+	}
+
+	var payload map[string]string
+	if err := json.Unmarshal((*requests)[0].body, &payload); err != nil {
+		t.Fatalf("invalid payload: %v", err)
+	}
+	want := strings.Join([]string{
+		"*IONOS Cloud Watchdog: CRITICAL, issues changed*",
+		"- New: [CRITICAL] IONOS authentication failed",
+		"- Changed: [CRITICAL] Pod ns/a Pending",
+		"- Resolved: DC prod: Server web-1 state: ERROR",
+	}, "\n")
+	if len(payload) != 1 || payload["text"] != want {
+		t.Fatalf("unexpected slack payload: %v", payload)
+	}
+}
+
+func TestTeamsNotifier_PostsMessageCard(t *testing.T) {
+	server, requests := newCaptureServer(t, http.StatusOK)
+
+	event := testEvent()
+	event.Reminder = true
+	notifier := &TeamsNotifier{WebhookURL: server.URL}
+	if err := notifier.Notify(context.Background(), event); err != nil {
+		t.Fatalf("Notify returned error: %v", err)
+	}
+
+	var card map[string]string
+	if err := json.Unmarshal((*requests)[0].body, &card); err != nil {
+		t.Fatalf("invalid payload: %v", err)
+	}
+	if card["@type"] != "MessageCard" || card["themeColor"] != "E01E5A" {
+		t.Fatalf("unexpected card: %v", card)
+	}
+	if card["title"] != "IONOS Cloud Watchdog: still CRITICAL (1 active issues)" {
+		t.Fatalf("unexpected title: %s", card["title"])
+	}
+}
+
+func TestTeamsNotifier_CardFollowsStatus(t *testing.T) {
+	for _, tc := range []struct {
+		status string
+		color  string
+	}{
+		{"CRITICAL", "E01E5A"},
+		{"WARNING", "ECB22E"},
+		{"OK", "2EB67D"},
+	} {
+		server, requests := newCaptureServer(t, http.StatusOK)
+
+		event := Event{Status: tc.status, PreviousStatus: "UNKNOWN", Opened: []output.Issue{podIssue}, Resolved: []output.Issue{authIssue}}
+		notifier := &TeamsNotifier{WebhookURL: server.URL}
+		if err := notifier.Notify(context.Background(), event); err != nil {
+			t.Fatalf("Notify returned error: %v", err)
+		}
+
+		var card map[string]string
+		if err := json.Unmarshal((*requests)[0].body, &card); err != nil {
+			t.Fatalf("invalid payload: %v", err)
+		}
+		title := "IONOS Cloud Watchdog: " + tc.status + " (was UNKNOWN)"
+		if card["themeColor"] != tc.color || card["title"] != title || card["summary"] != title {
+			t.Errorf("%s: unexpected card: %v", tc.status, card)
+		}
+		if card["text"] != "- New: [WARNING] Pod ns/a Pending\n\n- Resolved: IONOS authentication failed" {
+			t.Errorf("%s: unexpected text: %q", tc.status, card["text"])
+		}
+	}
+}
+
+func TestIssueLines_TruncatesLongLists(t *testing.T) {
+	event := Event{Status: "WARNING", PreviousStatus: "OK"}
+	for range maxListedIssues + 5 {
+		event.Opened = append(event.Opened, podIssue)
+	}
+
+	lines := issueLines(event)
+	if len(lines) != maxListedIssues+1 || lines[maxListedIssues] != "- ... and 5 more" {
+		t.Fatalf("unexpected lines: %v", lines[len(lines)-1])
+	}
+}
@@ -0,0 +1,237 @@
+package notify
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/peterpisarcik/ionos-cloud-watchdog/internal/output"
+)
+
+const (
+	DefaultPagerDutyURL   = "https://events.pagerduty.com/v2/enqueue"
+	DefaultOpsgenieAPIURL = "https://api.opsgenie.com"
+
+	defaultSource = "ionos-cloud-watchdog"
+)
+
+// DedupKey derives a stable identifier for an issue from its check and
+// resource, so the same problem maps to the same incident across runs and
+// different servers or node pools of one datacenter or cluster get their own
+// incidents.
+func DedupKey(issue output.Issue) string {
+	sum := sha256.Sum256([]byte(issue.Key()))
+	return defaultSource + "-" + hex.EncodeToString(sum[:16])
+}
+
+func sourceOrDefault(source string) string {
+	if source != "" {
+		return source
+	}
+	return defaultSource
+}
+
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n-3]) + "..."
+}
+
+func issueDetails(issue output.Issue) map[string]string {
+	details := map[string]string{
+		"check":     issue.Check,
+		"subsystem": issue.Subsystem,
+	}
+	if issue.Hint != "" {
+		details["hint"] = issue.Hint
+	}
+	if issue.Resource.Kind != "" {
+		details["kind"] = issue.Resource.Kind
+	}
+	if issue.Resource.Namespace != "" {
+		details["namespace"] = issue.Resource.Namespace
+	}
+	if issue.Resource.Name != "" {
+		details["name"] = issue.Resource.Name
+	}
+	if issue.Resource.ID != "" {
+		details["id"] = issue.Resource.ID
+	}
+	if label := parentLabel(issue.Resource.Parent); label != "" {
+		details[label] = issue.Resource.Parent.Name
+		details[label+"_id"] = issue.Resource.Parent.ID
+	}
+	if issue.Reason != "" {
+		details["reason"] = issue.Reason
+	}
+	return details
+}
+
+// parentLabel names the datacenter or cluster of a resource in labels and
+// details.
+func parentLabel(parent output.ResourceRef) string {
+	switch parent.Kind {
+	case "Datacenter":
+		return "datacenter"
+	case "K8sCluster":
+		return "cluster"
+	}
+	return ""
+}
+
+// incidentActions splits an event into the issues to (re)open and to close.
+// Reminders re-send every active issue, which both providers deduplicate, so
+// an incident whose first delivery failed is eventually opened.
+func incidentActions(event Event) (trigger, resolve []output.Issue) {
+	if event.Reminder {
+		return event.Active, nil
+	}
+	trigger = append(append(trigger, event.Opened...), event.Updated...)
+	return trigger, event.Resolved
+}
+
+type PagerDutyNotifier struct {
+	RoutingKey string
+	URL        string
+	Source     string
+	Client     *http.Client
+}
+
+type pagerDutyEvent struct {
+	RoutingKey  string            `json:"routing_key"`
+	EventAction string            `json:"event_action"`
+	DedupKey    string            `json:"dedup_key"`
+	Payload     *pagerDutyPayload `json:"payload,omitempty"`
+}
+
+type pagerDutyPayload struct {
+	Summary       string            `json:"summary"`
+	Source        string            `json:"source"`
+	Severity      string            `json:"severity"`
+	Component     string            `json:"component,omitempty"`
+	Group         string            `json:"group,omitempty"`
+	Class         string            `json:"class,omitempty"`
+	CustomDetails map[string]string `json:"custom_details,omitempty"`
+}
+
+func (p *PagerDutyNotifier) Name() string {
+	return "pagerduty"
+}
+
+func (p *PagerDutyNotifier) Notify(ctx context.Context, event Event) error {
+	endpoint := p.URL
+	if endpoint == "" {
+		endpoint = DefaultPagerDutyURL
+	}
+
+	var errs []error
+	trigger, resolve := incidentActions(event)
+	for _, issue := range trigger {
+		if err := postJSON(ctx, p.Client, endpoint, nil, pagerDutyEvent{
+			RoutingKey:  p.RoutingKey,
+			EventAction: "trigger",
+			DedupKey:    DedupKey(issue),
+			Payload: &pagerDutyPayload{
+				Summary:       truncate(issue.Message, 1024),
+				Source:        sourceOrDefault(p.Source),
+				Severity:      string(issue.Severity),
+				Component:     issue.Resource.Name,
+				Group:         issue.Subsystem,
+				Class:         issue.Check,
+				CustomDetails: issueDetails(issue),
+			},
+		}); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	for _, issue := range resolve {
+		if err := postJSON(ctx, p.Client, endpoint, nil, pagerDutyEvent{
+			RoutingKey:  p.RoutingKey,
+			EventAction: "resolve",
+			DedupKey:    DedupKey(issue),
+		}); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+type OpsgenieNotifier struct {
+	APIKey string
+	APIURL string
+	Source string
+	Tags   []string
+	Client *http.Client
+}
+
+type opsgenieAlert struct {
+	Message     string            `json:"message"`
+	Alias       string            `json:"alias"`
+	Description string            `json:"description,omitempty"`
+	Priority    string            `json:"priority"`
+	Source      string            `json:"source"`
+	Entity      string            `json:"entity,omitempty"`
+	Tags        []string          `json:"tags,omitempty"`
+	Details     map[string]string `json:"details,omitempty"`
+}
+
+type opsgenieClose struct {
+	Source string `json:"source"`
+	Note   string `json:"note,omitempty"`
+}
+
+func (o *OpsgenieNotifier) Name() string {
+	return "opsgenie"
+}
+
+func (o *OpsgenieNotifier) Notify(ctx context.Context, event Event) error {
+	base := strings.TrimSuffix(o.APIURL, "/")
+	if base == "" {
+		base = DefaultOpsgenieAPIURL
+	}
+	headers := map[string]string{"Authorization": "GenieKey " + o.APIKey}
+
+	var errs []error
+	trigger, resolve := incidentActions(event)
+	for _, issue := range trigger {
+		if err := postJSON(ctx, o.Client, base+"/v2/alerts", headers, opsgenieAlert{
+			Message:     truncate(issue.Message, 130),
+			Alias:       DedupKey(issue),
+			Description: issue.Hint,
+			Priority:    opsgeniePriority(issue.Severity),
+			Source:      sourceOrDefault(o.Source),
+			Entity:      issue.Resource.Name,
+			Tags:        o.Tags,
+			Details:     issueDetails(issue),
+		}); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	for _, issue := range resolve {
+		closeURL := base + "/v2/alerts/" + url.PathEscape(DedupKey(issue)) + "/close?identifierType=alias"
+		if err := postJSON(ctx, o.Client, closeURL, headers, opsgenieClose{
+			Source: sourceOrDefault(o.Source),
+			Note:   "Resolved: " + issue.Message,
+		}); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func opsgeniePriority(severity output.Severity) string {
+	switch severity {
+	case output.SeverityCritical:
+		return "P1"
+	case output.SeverityWarning:
+		return "P3"
+	default:
+		return "P5"
+	}
+}
@@ -0,0 +1,151 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/peterpisarcik/ionos-cloud-watchdog/internal/output"
+)
+
+func TestDedupKey_StableAcrossMessageChanges(t *testing.T) {
+	changed := podIssue
+	changed.Message = "Pod ns/a CrashLoopBackOff"
+
+	if DedupKey(podIssue) != DedupKey(changed) {
+		t.Fatalf("expected dedup key to depend on check and resource only")
+	}
+	if DedupKey(podIssue) == DedupKey(authIssue) {
+		t.Fatalf("expected different issues to have different dedup keys")
+	}
+	if !strings.HasPrefix(DedupKey(authIssue), "ionos-cloud-watchdog-") {
+		t.Fatalf("unexpected dedup key: %s", DedupKey(authIssue))
+	}
+}
+
+func TestPagerDutyNotifier_IncidentPerServer(t *testing.T) {
+	server, requests := newCaptureServer(t, http.StatusAccepted)
+
+	d := NewDispatcher(0, &PagerDutyNotifier{RoutingKey: "rk", URL: server.URL})
+	web1, web2 := serverIssue("srv-1", "web-1"), serverIssue("srv-2", "web-2")
+	if DedupKey(web1) == DedupKey(web2) {
+		t.Fatalf("expected servers in one datacenter to have different dedup keys")
+	}
+
+	for _, report := range []*output.Report{
+		{Status: "WARNING", Issues: []output.Issue{web1, web2}},
+		{Status: "WARNING", Issues: []output.Issue{web2}},
+	} {
+		if err := d.Dispatch(context.Background(), report); err != nil {
+			t.Fatalf("Dispatch returned error: %v", err)
+		}
+	}
+
+	var actions []string
+	for _, req := range *requests {
+		var event pagerDutyEvent
+		if err := json.Unmarshal(req.body, &event); err != nil {
+			t.Fatalf("invalid payload: %v", err)
+		}
+		actions = append(actions, event.EventAction+" "+event.DedupKey)
+		if event.Payload != nil && event.Payload.CustomDetails["datacenter_id"] != "dc-1" {
+			t.Fatalf("expected the datacenter in the details, got %v", event.Payload.CustomDetails)
+		}
+	}
+	want := []string{"trigger " + DedupKey(web1), "trigger " + DedupKey(web2), "resolve " + DedupKey(web1)}
+	if strings.Join(actions, ",") != strings.Join(want, ",") {
+		t.Fatalf("expected %v, got %v", want, actions)
+	}
+}
+
+func TestPagerDutyNotifier_IncidentLifecycle(t *testing.T) {
+	server, requests := newCaptureServer(t, http.StatusAccepted)
+
+	d := NewDispatcher(0, &PagerDutyNotifier{RoutingKey: "rk", URL: server.URL})
+	ctx := context.Background()
+
+	escalated := podIssue
+	escalated.Severity = output.SeverityCritical
+
+	for _, report := range []*output.Report{
+		{Status: "WARNING", Issues: []output.Issue{podIssue}},
+		{Status: "CRITICAL", Issues: []output.Issue{escalated}},
+		{Status: "OK"},
+	} {
+		if err := d.Dispatch(ctx, report); err != nil {
+			t.Fatalf("Dispatch returned error: %v", err)
+		}
+	}
+
+	if len(*requests) != 3 {
+		t.Fatalf("expected trigger, update and resolve, got %d requests", len(*requests))
+	}
+
+	var events []pagerDutyEvent
+	for _, req := range *requests {
+		var event pagerDutyEvent
+		if err := json.Unmarshal(req.body, &event); err != nil {
+			t.Fatalf("invalid payload: %v", err)
+		}
+		if event.RoutingKey != "rk" || event.DedupKey != DedupKey(podIssue) {
+			t.Fatalf("unexpected routing or dedup key: %+v", event)
+		}
+		events = append(events, event)
+	}
+
+	if events[0].EventAction != "trigger" || events[0].Payload.Severity != "warning" || events[0].Payload.Class != output.CheckPods {
+		t.Fatalf("unexpected trigger: %+v", events[0])
+	}
+	if events[1].EventAction != "trigger" || events[1].Payload.Severity != "critical" {
+		t.Fatalf("expected update to re-trigger with new severity: %+v", events[1])
+	}
+	if events[2].EventAction != "resolve" || events[2].Payload != nil {
+		t.Fatalf("unexpected resolve: %+v", events[2])
+	}
+}
+
+func TestOpsgenieNotifier_CreatesAndClosesAlerts(t *testing.T) {
+	var paths []string
+	var bodies []map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "GenieKey key" {
+			t.Errorf("unexpected authorization header: %q", r.Header.Get("Authorization"))
+		}
+		paths = append(paths, r.URL.RequestURI())
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		bodies = append(bodies, body)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	notifier := &OpsgenieNotifier{APIKey: "key", APIURL: server.URL, Tags: []string{"ionos"}}
+	ctx := context.Background()
+
+	if err := notifier.Notify(ctx, Event{Status: "CRITICAL", PreviousStatus: "OK", Opened: []output.Issue{authIssue}}); err != nil {
+		t.Fatalf("Notify returned error: %v", err)
+	}
+	if err := notifier.Notify(ctx, Event{Status: "OK", PreviousStatus: "CRITICAL", Resolved: []output.Issue{authIssue}}); err != nil {
+		t.Fatalf("Notify returned error: %v", err)
+	}
+
+	if len(paths) != 2 {
+		t.Fatalf("expected 2 requests, got %d", len(paths))
+	}
+	if paths[0] != "/v2/alerts" || bodies[0]["alias"] != DedupKey(authIssue) || bodies[0]["priority"] != "P1" {
+		t.Fatalf("unexpected create request %s: %v", paths[0], bodies[0])
+	}
+	if paths[1] != "/v2/alerts/"+DedupKey(authIssue)+"/close?identifierType=alias" {
+		t.Fatalf("unexpected close request: %s", paths[1])
+	}
+}
+
+func TestIncidentActions_ReminderRetriggersActiveIssues(t *testing.T) {
+	trigger, resolve := incidentActions(Event{Reminder: true, Active: []output.Issue{authIssue, podIssue}})
+	if len(trigger) != 2 || len(resolve) != 0 {
+		t.Fatalf("expected all active issues to be re-triggered, got %v / %v", trigger, resolve)
+	}
+}
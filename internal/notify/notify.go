@@ -0,0 +1,203 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/peterpisarcik/ionos-cloud-watchdog/internal/config"
+	"github.com/peterpisarcik/ionos-cloud-watchdog/internal/output"
+)
+
+const (
+	EventStatusChanged = "status_changed"
+	EventIssuesChanged = "issues_changed"
+	EventReminder      = "reminder"
+)
+
+type Event struct {
+	Status         string
+	PreviousStatus string
+	Opened         []output.Issue
+	Updated        []output.Issue
+	Resolved       []output.Issue
+	Active         []output.Issue
+	Reminder       bool
+	Time           time.Time
+	Report         *output.Report
+}
+
+func (e Event) Kind() string {
+	switch {
+	case e.Reminder:
+		return EventReminder
+	case e.Status != e.PreviousStatus:
+		return EventStatusChanged
+	default:
+		return EventIssuesChanged
+	}
+}
+
+type Notifier interface {
+	Name() string
+	Notify(ctx context.Context, event Event) error
+}
+
+// everyRunNotifier is implemented by notifiers that must receive the current
+// state after every run, not only when it changes.
+type everyRunNotifier interface {
+	notifyEveryRun() bool
+}
+
+func notifiesEveryRun(n Notifier) bool {
+	e, ok := n.(everyRunNotifier)
+	return ok && e.notifyEveryRun()
+}
+
+// Dispatcher remembers the status and issues of the previous run and only
+// notifies when they change, or when a non-OK status has been reported
+// unchanged for longer than the reminder interval.
+type Dispatcher struct {
+	notifiers        []Notifier
+	reminderInterval time.Duration
+	now              func() time.Time
+
+	mu           sync.Mutex
+	status       string
+	active       []output.Issue
+	lastNotified time.Time
+}
+
+func NewDispatcher(reminderInterval time.Duration, notifiers ...Notifier) *Dispatcher {
+	return &Dispatcher{
+		notifiers:        notifiers,
+		reminderInterval: reminderInterval,
+		now:              time.Now,
+	}
+}
+
+// New builds the configured notifiers. interval is the time between runs.
+func New(cfg config.NotificationsConfig, interval time.Duration) *Dispatcher {
+	client := &http.Client{Timeout: 10 * time.Second}
+
+	var notifiers []Notifier
+	for _, webhook := range cfg.Webhooks {
+		notifiers = append(notifiers, &WebhookNotifier{URL: webhook.URL, Headers: webhook.Headers, Client: client})
+	}
+	for _, slack := range cfg.Slack {
+		notifiers = append(notifiers, &SlackNotifier{WebhookURL: slack.WebhookURL, Client: client})
+	}
+	for _, teams := range cfg.Teams {
+		notifiers = append(notifiers, &TeamsNotifier{WebhookURL: teams.WebhookURL, Client: client})
+	}
+	for _, pd := range cfg.PagerDuty {
+		notifiers = append(notifiers, &PagerDutyNotifier{RoutingKey: pd.RoutingKey, URL: pd.URL, Source: pd.Source, Client: client})
+	}
+	for _, og := range cfg.Opsgenie {
+		notifiers = append(notifiers, &OpsgenieNotifier{APIKey: og.APIKey, APIURL: og.APIURL, Source: og.Source, Tags: og.Tags, Client: client})
+	}
+	for _, am := range cfg.Alertmanager {
+		notifiers = append(notifiers, &AlertmanagerNotifier{URL: am.URL, Labels: am.Labels, Headers: am.Headers, Client: client, Interval: interval})
+	}
+
+	return NewDispatcher(cfg.ReminderInterval, notifiers...)
+}
+
+func (d *Dispatcher) Enabled() bool {
+	return d != nil && len(d.notifiers) > 0
+}
+
+func (d *Dispatcher) Dispatch(ctx context.Context, report *output.Report) error {
+	if !d.Enabled() {
+		return nil
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	event, changed := d.observe(report)
+
+	var errs []error
+	for _, notifier := range d.notifiers {
+		if !changed && !notifiesEveryRun(notifier) {
+			continue
+		}
+		if err := notifier.Notify(ctx, event); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", notifier.Name(), err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// observe records the report as the new state and reports whether the event
+// is worth a notification. The state is updated even if delivery fails later,
+// so a broken endpoint does not cause a notification storm once it recovers.
+func (d *Dispatcher) observe(report *output.Report) (Event, bool) {
+	current := make(map[string]bool)
+	var active []output.Issue
+	for _, issue := range report.Issues {
+		key := issue.Key()
+		if current[key] {
+			continue
+		}
+		current[key] = true
+		active = append(active, issue)
+	}
+
+	known := make(map[string]output.Issue)
+	var resolved []output.Issue
+	for _, issue := range d.active {
+		known[issue.Key()] = issue
+		if !current[issue.Key()] {
+			resolved = append(resolved, issue)
+		}
+	}
+
+	// Only a changed severity counts as an update; message details such as
+	// "expires in N days" change on every run and would cause noise.
+	var opened, updated []output.Issue
+	for _, issue := range active {
+		previous, ok := known[issue.Key()]
+		switch {
+		case !ok:
+			opened = append(opened, issue)
+		case previous.Severity != issue.Severity:
+			updated = append(updated, issue)
+		}
+	}
+
+	previous := d.status
+	if previous == "" {
+		previous = "OK"
+	}
+
+	now := d.now()
+	event := Event{
+		Status:         report.Status,
+		PreviousStatus: previous,
+		Opened:         opened,
+		Updated:        updated,
+		Resolved:       resolved,
+		Active:         active,
+		Time:           now,
+		Report:         report,
+	}
+
+	changed := report.Status != previous || len(opened) > 0 || len(updated) > 0 || len(resolved) > 0
+	if !changed {
+		if d.reminderInterval <= 0 || report.Status == "OK" || now.Sub(d.lastNotified) < d.reminderInterval {
+			d.status = report.Status
+			d.active = active
+			return event, false
+		}
+		event.Reminder = true
+	}
+
+	d.status = report.Status
+	d.active = active
+	d.lastNotified = now
+	return event, true
+}
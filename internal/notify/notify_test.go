@@ -0,0 +1,235 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/peterpisarcik/ionos-cloud-watchdog/internal/config"
+	"github.com/peterpisarcik/ionos-cloud-watchdog/internal/output"
+)
+
+type recordingNotifier struct {
+	events []Event
+	err    error
+}
+
+func (r *recordingNotifier) Name() string {
+	return "recording"
+}
+
+func (r *recordingNotifier) Notify(_ context.Context, event Event) error {
+	r.events = append(r.events, event)
+	return r.err
+}
+
+var (
+	podIssue = output.Issue{
+		Severity:  output.SeverityWarning,
+		Check:     output.CheckPods,
+		Subsystem: output.SubsystemKubernetes,
+		Resource:  output.Resource{Kind: "Pod", Namespace: "ns", Name: "a"},
+		Message:   "Pod ns/a Pending",
+	}
+	authIssue = output.Issue{
+		Severity:  output.SeverityCritical,
+		Check:     output.CheckAuth,
+		Subsystem: output.SubsystemIONOS,
+		Message:   "IONOS authentication failed",
+	}
+)
+
+func serverIssue(id, name string) output.Issue {
+	return output.Issue{
+		Severity:  output.SeverityWarning,
+		Check:     output.CheckDatacenters,
+		Subsystem: output.SubsystemIONOS,
+		Resource:  output.Resource{Kind: "Server", Name: name, ID: id, Parent: output.ResourceRef{Kind: "Datacenter", Name: "prod", ID: "dc-1"}},
+		Message:   "DC prod: Server " + name + " state: ERROR",
+	}
+}
+
+func newTestDispatcher(reminder time.Duration) (*Dispatcher, *recordingNotifier, *time.Time) {
+	recorder := &recordingNotifier{}
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	d := NewDispatcher(reminder, recorder)
+	d.now = func() time.Time { return now }
+	return d, recorder, &now
+}
+
+func dispatch(t *testing.T, d *Dispatcher, status string, issues ...output.Issue) {
+	t.Helper()
+	if err := d.Dispatch(context.Background(), &output.Report{Status: status, Issues: issues}); err != nil {
+		t.Fatalf("Dispatch returned error: %v", err)
+	}
+}
+
+func TestDispatcher_NotifiesOnTransitions(t *testing.T) {
+	d, recorder, _ := newTestDispatcher(0)
+
+	dispatch(t, d, "OK")
+	if len(recorder.events) != 0 {
+		t.Fatalf("expected no notification for an initial OK run, got %d", len(recorder.events))
+	}
+
+	dispatch(t, d, "WARNING", podIssue)
+	dispatch(t, d, "WARNING", podIssue)
+	dispatch(t, d, "CRITICAL", podIssue, authIssue)
+	dispatch(t, d, "OK")
+
+	if len(recorder.events) != 3 {
+		t.Fatalf("expected 3 notifications, got %d", len(recorder.events))
+	}
+
+	first := recorder.events[0]
+	if first.Kind() != EventStatusChanged || first.PreviousStatus != "OK" || len(first.Opened) != 1 {
+		t.Fatalf("unexpected first event: %+v", first)
+	}
+
+	second := recorder.events[1]
+	if second.Status != "CRITICAL" || len(second.Opened) != 1 || second.Opened[0].Check != output.CheckAuth {
+		t.Fatalf("expected only the new auth issue to be opened, got %+v", second)
+	}
+
+	last := recorder.events[2]
+	if last.Status != "OK" || len(last.Resolved) != 2 || len(last.Active) != 0 {
+		t.Fatalf("expected both issues resolved, got %+v", last)
+	}
+}
+
+func TestDispatcher_NewIssueWithoutStatusChange(t *testing.T) {
+	d, recorder, _ := newTestDispatcher(0)
+
+	dispatch(t, d, "CRITICAL", authIssue)
+	dispatch(t, d, "CRITICAL", authIssue, podIssue)
+
+	if len(recorder.events) != 2 {
+		t.Fatalf("expected 2 notifications, got %d", len(recorder.events))
+	}
+	if kind := recorder.events[1].Kind(); kind != EventIssuesChanged {
+		t.Fatalf("expected %s, got %s", EventIssuesChanged, kind)
+	}
+}
+
+func TestDispatcher_SeparatesResourcesInOneDatacenter(t *testing.T) {
+	d, recorder, _ := newTestDispatcher(0)
+
+	dispatch(t, d, "WARNING", serverIssue("srv-1", "web-1"))
+	dispatch(t, d, "WARNING", serverIssue("srv-1", "web-1"), serverIssue("srv-2", "web-2"))
+
+	if len(recorder.events) != 2 {
+		t.Fatalf("expected the second server to be notified, got %d events", len(recorder.events))
+	}
+	second := recorder.events[1]
+	if len(second.Opened) != 1 || second.Opened[0].Resource.ID != "srv-2" || len(second.Active) != 2 {
+		t.Fatalf("expected web-2 opened next to web-1, got %+v", second)
+	}
+}
+
+func TestDispatcher_DeduplicatesByResource(t *testing.T) {
+	d, recorder, _ := newTestDispatcher(0)
+
+	dispatch(t, d, "WARNING", podIssue, podIssue)
+
+	changed := podIssue
+	changed.Message = "Pod ns/a Pending for 5m"
+	dispatch(t, d, "WARNING", changed)
+
+	if len(recorder.events) != 1 {
+		t.Fatalf("expected a single notification, got %d", len(recorder.events))
+	}
+	if len(recorder.events[0].Active) != 1 {
+		t.Fatalf("expected duplicate issues to be collapsed, got %v", recorder.events[0].Active)
+	}
+}
+
+func TestDispatcher_SeparatesReasonsOfOneResource(t *testing.T) {
+	d, recorder, _ := newTestDispatcher(0)
+
+	memory := output.Issue{
+		Severity:  output.SeverityWarning,
+		Check:     output.CheckNodeConditions,
+		Subsystem: output.SubsystemKubernetes,
+		Resource:  output.Resource{Kind: "Node", Name: "node-2"},
+		Reason:    "MemoryPressure",
+		Message:   "Node node-2 MemoryPressure",
+	}
+	disk := memory
+	disk.Reason = "DiskPressure"
+	disk.Message = "Node node-2 DiskPressure"
+
+	dispatch(t, d, "WARNING", memory)
+	dispatch(t, d, "WARNING", memory, disk)
+	dispatch(t, d, "WARNING", disk)
+
+	if len(recorder.events) != 3 {
+		t.Fatalf("expected 3 notifications, got %d", len(recorder.events))
+	}
+	if second := recorder.events[1]; len(second.Opened) != 1 || second.Opened[0].Reason != "DiskPressure" || len(second.Active) != 2 {
+		t.Fatalf("expected DiskPressure opened next to MemoryPressure, got %+v", second)
+	}
+	if third := recorder.events[2]; len(third.Resolved) != 1 || third.Resolved[0].Reason != "MemoryPressure" {
+		t.Fatalf("expected MemoryPressure resolved, got %+v", third)
+	}
+}
+
+func TestDispatcher_Reminders(t *testing.T) {
+	d, recorder, now := newTestDispatcher(time.Hour)
+
+	dispatch(t, d, "CRITICAL", authIssue)
+
+	*now = now.Add(30 * time.Minute)
+	dispatch(t, d, "CRITICAL", authIssue)
+	if len(recorder.events) != 1 {
+		t.Fatalf("expected no reminder before the interval, got %d events", len(recorder.events))
+	}
+
+	*now = now.Add(31 * time.Minute)
+	dispatch(t, d, "CRITICAL", authIssue)
+	if len(recorder.events) != 2 || !recorder.events[1].Reminder {
+		t.Fatalf("expected a reminder after the interval, got %+v", recorder.events)
+	}
+
+	*now = now.Add(30 * time.Minute)
+	dispatch(t, d, "CRITICAL", authIssue)
+	if len(recorder.events) != 2 {
+		t.Fatalf("expected the reminder to reset the interval, got %d events", len(recorder.events))
+	}
+}
+
+func TestDispatcher_ReturnsNotifierErrors(t *testing.T) {
+	d, recorder, _ := newTestDispatcher(0)
+	recorder.err = errors.New("boom")
+
+	err := d.Dispatch(context.Background(), &output.Report{Status: "CRITICAL", Issues: []output.Issue{authIssue}})
+	if err == nil || err.Error() != "recording: boom" {
+		t.Fatalf("expected notifier error, got %v", err)
+	}
+
+	if err := d.Dispatch(context.Background(), &output.Report{Status: "CRITICAL", Issues: []output.Issue{authIssue}}); err != nil {
+		t.Fatalf("expected no repeated delivery for unchanged state, got %v", err)
+	}
+}
+
+func TestNew_BuildsConfiguredNotifiers(t *testing.T) {
+	d := New(config.NotificationsConfig{
+		Webhooks:     []config.WebhookConfig{{URL: "https://example.com/hook"}},
+		Slack:        []config.SlackConfig{{WebhookURL: "https://hooks.slack.com/services/x"}},
+		Teams:        []config.TeamsConfig{{WebhookURL: "https://example.webhook.office.com/x"}},
+		Alertmanager: []config.AlertmanagerConfig{{URL: "https://alertmanager.example.com"}},
+	}, time.Minute)
+
+	if len(d.notifiers) != 4 {
+		t.Fatalf("expected 4 notifiers, got %d", len(d.notifiers))
+	}
+	if am, ok := d.notifiers[3].(*AlertmanagerNotifier); !ok || am.Interval != time.Minute {
+		t.Fatalf("expected the run interval on the Alertmanager notifier, got %+v", d.notifiers[3])
+	}
+	if !d.Enabled() {
+		t.Fatalf("expected dispatcher to be enabled")
+	}
+	if New(config.NotificationsConfig{}, time.Minute).Enabled() {
+		t.Fatalf("expected dispatcher without notifiers to be disabled")
+	}
+}
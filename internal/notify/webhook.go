@@ -0,0 +1,93 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/peterpisarcik/ionos-cloud-watchdog/internal/output"
+)
+
+type WebhookNotifier struct {
+	URL     string
+	Headers map[string]string
+	Client  *http.Client
+}
+
+type webhookPayload struct {
+	Event          string             `json:"event"`
+	Status         string             `json:"status"`
+	PreviousStatus string             `json:"previous_status"`
+	Timestamp      time.Time          `json:"timestamp"`
+	Opened         []output.JSONIssue `json:"opened"`
+	Updated        []output.JSONIssue `json:"updated"`
+	Resolved       []output.JSONIssue `json:"resolved"`
+	Active         []output.JSONIssue `json:"active"`
+	Report         *output.JSONReport `json:"report,omitempty"`
+}
+
+func (w *WebhookNotifier) Name() string {
+	return "webhook"
+}
+
+func (w *WebhookNotifier) Notify(ctx context.Context, event Event) error {
+	payload := webhookPayload{
+		Event:          event.Kind(),
+		Status:         event.Status,
+		PreviousStatus: event.PreviousStatus,
+		Timestamp:      event.Time.UTC(),
+		Opened:         jsonIssues(event.Opened),
+		Updated:        jsonIssues(event.Updated),
+		Resolved:       jsonIssues(event.Resolved),
+		Active:         jsonIssues(event.Active),
+	}
+	if event.Report != nil {
+		report := output.NewJSONReport(event.Report)
+		payload.Report = &report
+	}
+
+	return postJSON(ctx, w.Client, w.URL, w.Headers, payload)
+}
+
+func jsonIssues(issues []output.Issue) []output.JSONIssue {
+	out := []output.JSONIssue{}
+	for _, issue := range issues {
+		out = append(out, output.NewJSONIssue(issue))
+	}
+	return out
+}
+
+func postJSON(ctx context.Context, client *http.Client, url string, headers map[string]string, payload any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("endpoint returned status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/peterpisarcik/ionos-cloud-watchdog/internal/output"
+)
+
+type capturedRequest struct {
+	header http.Header
+	body   []byte
+}
+
+func newCaptureServer(t *testing.T, status int) (*httptest.Server, *[]capturedRequest) {
+	t.Helper()
+	var requests []capturedRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("expected POST, got %s", r.Method)
+		}
+		body, _ := io.ReadAll(r.Body)
+		requests = append(requests, capturedRequest{header: r.Header.Clone(), body: body})
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+func testEvent() Event {
+	return Event{
+		Status:         "CRITICAL",
+		PreviousStatus: "OK",
+		Opened:         []output.Issue{authIssue},
+		Active:         []output.Issue{authIssue},
+		Time:           time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC),
+		Report:         &output.Report{Status: "CRITICAL", Issues: []output.Issue{authIssue}},
+	}
+}
+
+func TestWebhookNotifier_PostsEvent(t *testing.T) {
+	server, requests := newCaptureServer(t, http.StatusNoContent)
+
+	notifier := &WebhookNotifier{URL: server.URL, Headers: map[string]string{"Authorization": "Bearer secret"}}
+	if err := notifier.Notify(context.Background(), testEvent()); err != nil {
+		t.Fatalf("Notify returned error: %v", err)
+	}
+
+	if len(*requests) != 1 {
+		t.Fatalf("expected 1 request, got %d", len(*requests))
+	}
+	req := (*requests)[0]
+	if req.header.Get("Authorization") != "Bearer secret" || req.header.Get("Content-Type") != "application/json" {
+		t.Fatalf("unexpected headers: %v", req.header)
+	}
+
+	var payload webhookPayload
+	if err := json.Unmarshal(req.body, &payload); err != nil {
+		t.Fatalf("invalid payload: %v", err)
+	}
+	if payload.Event != EventStatusChanged || payload.Status != "CRITICAL" || payload.PreviousStatus != "OK" {
+		t.Fatalf("unexpected payload: %+v", payload)
+	}
+	if len(payload.Opened) != 1 || payload.Opened[0].Check != output.CheckAuth {
+		t.Fatalf("unexpected opened issues: %+v", payload.Opened)
+	}
+	if payload.Resolved == nil || payload.Report == nil || payload.Report.SchemaVersion != output.SchemaVersion {
+		t.Fatalf("expected resolved list and report in payload: %s", req.body)
+	}
+}
+
+func TestPostJSON_ReportsErrorStatus(t *testing.T) {
+	server, _ := newCaptureServer(t, http.StatusInternalServerError)
+
+	notifier := &SlackNotifier{WebhookURL: server.URL}
+	err := notifier.Notify(context.Background(), testEvent())
+	if err == nil || !strings.Contains(err.Error(), "status 500") {
+		t.Fatalf("expected status error, got %v", err)
+	}
+}
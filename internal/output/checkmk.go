@@ -0,0 +1,85 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// WriteCheckmk prints one Checkmk local check line per service:
+// <state> "<service>" <metrics> <text>.
+func WriteCheckmk(w io.Writer, report *Report) error {
+	var b strings.Builder
+	for _, s := range buildServices(report, false) {
+		text := s.Text()
+		if text == "" {
+			text = s.Status()
+		}
+		fmt.Fprintf(&b, "%d \"%s\" %s %s\n", defaultExitCodes[s.Status()], strings.ReplaceAll(s.Name, `"`, "'"), checkmkMetrics(s.Metrics), nagiosText(text))
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func checkmkMetrics(metrics []serviceMetric) string {
+	if len(metrics) == 0 {
+		return "-"
+	}
+	parts := make([]string, 0, len(metrics))
+	for _, metric := range metrics {
+		parts = append(parts, fmt.Sprintf("%s=%d", metric.Name, metric.Value))
+	}
+	return strings.Join(parts, "|")
+}
+
+type zabbixDiscovery struct {
+	Status     string                 `json:"status"`
+	StatusCode int                    `json:"status_code"`
+	Data       []map[string]string    `json:"data"`
+	Values     map[string]zabbixValue `json:"values"`
+}
+
+type zabbixValue struct {
+	Name       string         `json:"name"`
+	Status     string         `json:"status"`
+	StatusCode int            `json:"status_code"`
+	Issues     int            `json:"issues"`
+	Message    string         `json:"message"`
+	Metrics    map[string]int `json:"metrics"`
+}
+
+// WriteZabbixLLD prints a low-level discovery document. "data" holds the
+// discovery macros, "values" the item values keyed by {#SERVICE.ID} for
+// dependent items using JSONPath preprocessing.
+func WriteZabbixLLD(w io.Writer, report *Report) error {
+	out := zabbixDiscovery{
+		Status:     report.Status,
+		StatusCode: defaultExitCodes[report.Status],
+		Data:       []map[string]string{},
+		Values:     make(map[string]zabbixValue),
+	}
+	for _, s := range buildServices(report, false) {
+		out.Data = append(out.Data, map[string]string{
+			"{#SERVICE.ID}":    s.ID,
+			"{#SERVICE.NAME}":  s.Name,
+			"{#SERVICE.GROUP}": s.Group,
+		})
+		metrics := make(map[string]int, len(s.Metrics))
+		for _, metric := range s.Metrics {
+			metrics[metric.Name] = metric.Value
+		}
+		out.Values[s.ID] = zabbixValue{
+			Name:       s.Name,
+			Status:     s.Status(),
+			StatusCode: defaultExitCodes[s.Status()],
+			Issues:     len(s.Issues),
+			Message:    s.Text(),
+			Metrics:    metrics,
+		}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
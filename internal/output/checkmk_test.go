@@ -0,0 +1,133 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/peterpisarcik/ionos-cloud-watchdog/internal/feed"
+	"github.com/peterpisarcik/ionos-cloud-watchdog/internal/ionos"
+	"github.com/peterpisarcik/ionos-cloud-watchdog/internal/k8s"
+)
+
+func serviceReport() *Report {
+	dc := ionos.DataCenter{ID: "dc-1"}
+	dc.Properties.Name = "prod"
+	dc.Properties.Location = "de/fra"
+
+	pg := ionos.PostgreSQLCluster{ID: "pg-1"}
+	pg.Properties.DisplayName = "orders"
+	pg.Properties.PostgresVersion = "15"
+	pg.Properties.Location = "de/fra"
+	pg.Metadata.State = "BUSY"
+
+	return &Report{
+		Status:      "CRITICAL",
+		StatusPage:  &feed.StatusResult{Status: feed.StatusOK},
+		APICheck:    &ionos.CheckResult{OK: true, Message: "IONOS API is reachable"},
+		AuthCheck:   &ionos.CheckResult{OK: true},
+		Datacenters: []ionos.DatacenterStatus{{Datacenter: dc, Servers: make([]ionos.Server, 2), Issues: []ionos.ResourceIssue{{Kind: "Server", ID: "srv-1", Name: "web", Message: "Server web state: BUSY"}}}},
+		DBaaS:       &ionos.DBaaSStatus{PostgreSQL: []ionos.PostgreSQLCluster{pg}},
+		Health: &k8s.HealthResult{
+			Nodes: k8s.NodeResult{Total: 3, Ready: 2, NotReady: []string{"node-3"}},
+			Pods:  k8s.PodResult{Total: 4, Running: 4},
+		},
+		Issues: []Issue{
+			{Severity: SeverityWarning, Check: CheckDatacenters, Subsystem: SubsystemIONOS, Resource: Resource{Kind: "Server", Name: "web", ID: "srv-1", Parent: ResourceRef{Kind: "Datacenter", Name: "prod", ID: "dc-1"}}, Message: "DC prod: Server web state: BUSY"},
+			{Severity: SeverityWarning, Check: CheckDBaaS, Subsystem: SubsystemIONOS, Resource: Resource{Kind: "DBaaS", Name: "orders", ID: "pg-1"}, Message: "DBaaS: PostgreSQL cluster orders state: BUSY"},
+			{Severity: SeverityCritical, Check: CheckNodes, Subsystem: SubsystemKubernetes, Resource: Resource{Kind: "Node", Name: "node-3"}, Message: "Node node-3 NotReady"},
+			{Severity: SeverityCritical, Check: CheckTimeout, Subsystem: SubsystemIONOS, Message: "IONOS Cloud check timed out after 2m0s"},
+		},
+	}
+}
+
+func TestBuildServices_AssignsIssues(t *testing.T) {
+	services := make(map[string]*service)
+	for _, s := range buildServices(serviceReport(), false) {
+		services[s.ID] = s
+	}
+
+	for id, want := range map[string]int{
+		"statuspage":                  0,
+		"ionos.api":                   1,
+		"ionos.auth":                  0,
+		"ionos.datacenter.dc-1":       1,
+		"ionos.dbaas.postgresql.pg-1": 1,
+		"k8s.api":                     0,
+		"k8s.nodes":                   1,
+		"k8s.certificates":            0,
+	} {
+		s, ok := services[id]
+		if !ok {
+			t.Fatalf("expected service %s, got %v", id, services)
+		}
+		if len(s.Issues) != want {
+			t.Fatalf("expected %d issues for %s, got %v", want, id, s.Issues)
+		}
+	}
+	if services["ionos.api"].Issues[0].Check != CheckTimeout {
+		t.Fatalf("expected the timeout to fall back to the API service")
+	}
+}
+
+func TestBuildServices_SkipsUncheckedSubsystems(t *testing.T) {
+	services := buildServices(&Report{Status: "OK", StatusPage: &feed.StatusResult{Status: feed.StatusOK}}, false)
+	if len(services) != 1 || services[0].ID != "statuspage" {
+		t.Fatalf("expected only the status page service, got %d services", len(services))
+	}
+}
+
+func TestWriteCheckmk(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteCheckmk(&buf, serviceReport()); err != nil {
+		t.Fatalf("WriteCheckmk returned error: %v", err)
+	}
+	out := buf.String()
+
+	for _, want := range []string{
+		"0 \"IONOS Status Page\" incidents=0 OK\n",
+		"2 \"IONOS API\" - IONOS API is reachable, IONOS Cloud check timed out after 2m0s\n",
+		"1 \"IONOS Datacenter prod\" servers=2|volumes=0 de/fra, 2 servers, 0 volumes, DC prod: Server web state: BUSY\n",
+		"1 \"IONOS DBaaS postgresql orders\" - v15, de/fra, BUSY, DBaaS: PostgreSQL cluster orders state: BUSY\n",
+		"2 \"Kubernetes Nodes\" ready=2|total=3 2/3 ready, Node node-3 NotReady\n",
+		"0 \"Kubernetes Pods\" running=4|total=4 4/4 running\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in output:\n%s", want, out)
+		}
+	}
+}
+
+func TestWriteZabbixLLD(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteZabbixLLD(&buf, serviceReport()); err != nil {
+		t.Fatalf("WriteZabbixLLD returned error: %v", err)
+	}
+
+	var out zabbixDiscovery
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if out.Status != "CRITICAL" || out.StatusCode != 2 {
+		t.Fatalf("unexpected overall status: %s %d", out.Status, out.StatusCode)
+	}
+	if len(out.Data) != len(out.Values) {
+		t.Fatalf("expected a value per discovered service, got %d and %d", len(out.Data), len(out.Values))
+	}
+
+	var found bool
+	for _, macros := range out.Data {
+		if macros["{#SERVICE.ID}"] == "k8s.nodes" {
+			found = macros["{#SERVICE.NAME}"] == "Kubernetes Nodes" && macros["{#SERVICE.GROUP}"] == SubsystemKubernetes
+		}
+	}
+	if !found {
+		t.Fatalf("expected discovery macros for k8s.nodes, got %v", out.Data)
+	}
+
+	nodes := out.Values["k8s.nodes"]
+	if nodes.StatusCode != 2 || nodes.Issues != 1 || nodes.Metrics["ready"] != 2 || nodes.Metrics["total"] != 3 {
+		t.Fatalf("unexpected node values: %+v", nodes)
+	}
+}
@@ -2,9 +2,13 @@ package output
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 	"sync"
+	"time"
 
+	"github.com/peterpisarcik/ionos-cloud-watchdog/internal/config"
 	"github.com/peterpisarcik/ionos-cloud-watchdog/internal/feed"
 	"github.com/peterpisarcik/ionos-cloud-watchdog/internal/ionos"
 	"github.com/peterpisarcik/ionos-cloud-watchdog/internal/k8s"
@@ -13,154 +17,644 @@ import (
 var (
 	feedCheckStatus = feed.CheckStatus
 	newIONOSClient  = func() (ionosClient, error) { return ionos.NewClientFromEnv() }
-	newK8sChecker   = func(kubeconfig string) (k8sChecker, error) { return k8s.NewChecker(kubeconfig) }
+	newK8sChecker   = func(kubeconfig string, opts k8s.Options) (k8sChecker, error) {
+		return k8s.NewChecker(kubeconfig, opts)
+	}
 )
 
 type ionosClient interface {
-	CheckConnectivity() ionos.CheckResult
-	CheckAuthentication() ionos.CheckResult
-	CheckDatacenters() ([]ionos.DatacenterStatus, error)
-	CheckK8sClusters() ([]ionos.K8sClusterStatus, error)
-	CheckDBaaS() ionos.DBaaSStatus
+	CheckConnectivity(ctx context.Context) ionos.CheckResult
+	CheckAuthentication(ctx context.Context) ionos.CheckResult
+	CheckDatacenters(ctx context.Context) ([]ionos.DatacenterStatus, error)
+	CheckK8sClusters(ctx context.Context) ([]ionos.K8sClusterStatus, error)
+	CheckDBaaS(ctx context.Context) ionos.DBaaSStatus
 }
 
 type k8sChecker interface {
 	CheckHealth(ctx context.Context, namespace string) (*k8s.HealthResult, error)
 }
 
-func RunChecks(kubeconfig, namespace string) (*Report, error) {
-	report := &Report{Status: "OK"}
-	var issues []string
+const (
+	DefaultStatusPageTimeout = 15 * time.Second
+	DefaultIONOSTimeout      = 2 * time.Minute
+	DefaultKubernetesTimeout = time.Minute
+)
 
-	var wg sync.WaitGroup
+type Options struct {
+	Kubeconfig string
+	Namespace  string
+	Policy     config.PolicyConfig
+	Timeouts   Timeouts
+	Kubernetes config.KubernetesConfig
+}
 
-	wg.Add(3)
+type Timeouts struct {
+	StatusPage time.Duration
+	IONOS      time.Duration
+	Kubernetes time.Duration
+}
+
+func timeoutOrDefault(d, def time.Duration) time.Duration {
+	if d > 0 {
+		return d
+	}
+	return def
+}
 
-	go checkStatusPage(&wg, report, &issues)
-	go checkIONOS(&wg, report, &issues)
-	go checkK8s(&wg, report, &issues, kubeconfig, namespace)
+func RunChecks(ctx context.Context, opts Options) (*Report, error) {
+	startedAt := time.Now()
 
+	checks := []func(context.Context) *Report{
+		func(ctx context.Context) *Report {
+			return checkStatusPage(ctx, timeoutOrDefault(opts.Timeouts.StatusPage, DefaultStatusPageTimeout))
+		},
+		func(ctx context.Context) *Report {
+			return checkIONOS(ctx, timeoutOrDefault(opts.Timeouts.IONOS, DefaultIONOSTimeout))
+		},
+		func(ctx context.Context) *Report {
+			return checkK8s(ctx, opts.Kubeconfig, opts.Namespace, opts.Kubernetes, timeoutOrDefault(opts.Timeouts.Kubernetes, DefaultKubernetesTimeout))
+		},
+	}
+
+	// Each check fills its own partial report; merging them in a fixed order
+	// afterwards keeps the result deterministic and free of shared writes.
+	results := make([]*Report, len(checks))
+
+	var wg sync.WaitGroup
+	for i, check := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = check(ctx)
+		}()
+	}
 	wg.Wait()
 
-	report.Issues = issues
-	if len(issues) > 0 {
-		report.Status = "WARNING"
+	if errors.Is(ctx.Err(), context.Canceled) {
+		return nil, ctx.Err()
 	}
-	if len(issues) > 3 {
-		report.Status = "CRITICAL"
+
+	report := &Report{StartedAt: startedAt}
+	for _, result := range results {
+		mergeReport(report, result)
 	}
 
+	report.Issues = applyPolicy(report.Issues, opts.Policy)
+	report.Status = evaluateStatus(report.Issues, opts.Policy)
+	report.FinishedAt = time.Now()
+
 	return report, nil
 }
 
-func checkStatusPage(wg *sync.WaitGroup, report *Report, issues *[]string) {
-	defer wg.Done()
-	statusResult, err := feedCheckStatus()
+func mergeReport(dst, src *Report) {
+	if src.StatusPage != nil {
+		dst.StatusPage = src.StatusPage
+	}
+	if src.APICheck != nil {
+		dst.APICheck = src.APICheck
+	}
+	if src.AuthCheck != nil {
+		dst.AuthCheck = src.AuthCheck
+	}
+	if src.Datacenters != nil {
+		dst.Datacenters = src.Datacenters
+	}
+	if src.Clusters != nil {
+		dst.Clusters = src.Clusters
+	}
+	if src.DBaaS != nil {
+		dst.DBaaS = src.DBaaS
+	}
+	if src.Health != nil {
+		dst.Health = src.Health
+	}
+	dst.Issues = append(dst.Issues, src.Issues...)
+}
+
+// timeoutIssue reports a check that ran out of time. If the run itself hit
+// its deadline (--timeout), the check was cut short by that rather than by its
+// own timeout.
+func timeoutIssue(parent context.Context, severity Severity, subsystem, name string, timeout time.Duration) Issue {
+	if parent.Err() != nil {
+		return Issue{
+			Severity:  severity,
+			Check:     CheckTimeout,
+			Subsystem: subsystem,
+			Message:   fmt.Sprintf("%s check stopped by the overall timeout of the run", name),
+			Hint:      "Increase --timeout or lower the subsystem timeouts",
+		}
+	}
+	return Issue{
+		Severity:  severity,
+		Check:     CheckTimeout,
+		Subsystem: subsystem,
+		Message:   fmt.Sprintf("%s check timed out after %s", name, timeout),
+		Hint:      "Increase the timeout or check connectivity to the endpoint",
+	}
+}
+
+// setupIssue reports a subsystem that could not be checked at all because its
+// client could not be created.
+func setupIssue(subsystem, name string, err error, hint string) Issue {
+	return Issue{
+		Severity:  SeverityCritical,
+		Check:     CheckSetup,
+		Subsystem: subsystem,
+		Message:   fmt.Sprintf("%s check could not run: %v", name, err),
+		Hint:      hint,
+	}
+}
+
+// SetupError returns the subsystems that could not be checked, or nil. Nagios
+// reports them as UNKNOWN rather than as a critical result.
+func (r *Report) SetupError() error {
+	var errs []error
+	for _, issue := range r.Issues {
+		if issue.Check == CheckSetup {
+			errs = append(errs, errors.New(issue.Message))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func checkStatusPage(ctx context.Context, timeout time.Duration) *Report {
+	report := &Report{}
+
+	parent := ctx
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	statusResult, err := feedCheckStatus(ctx)
+	if ctx.Err() != nil {
+		report.Issues = append(report.Issues, timeoutIssue(parent, SeverityWarning, SubsystemStatusPage, "Status page", timeout))
+		return report
+	}
 	if err != nil {
-		*issues = append(*issues, fmt.Sprintf("Status page: %v", err))
+		report.Issues = append(report.Issues, Issue{
+			Severity:  SeverityWarning,
+			Check:     CheckStatusPage,
+			Subsystem: SubsystemStatusPage,
+			Message:   fmt.Sprintf("Status page: %v", err),
+			Hint:      "Check connectivity to status.ionos.cloud",
+		})
 	} else {
 		report.StatusPage = statusResult
 		if statusResult.Status != feed.StatusOK {
 			if len(statusResult.ActiveIncidents) > 0 {
 				for _, incident := range statusResult.ActiveIncidents {
-					*issues = append(*issues, fmt.Sprintf("Status page: %s", incident.Title))
+					report.Issues = append(report.Issues, Issue{
+						Severity:  SeverityWarning,
+						Check:     CheckStatusPage,
+						Subsystem: SubsystemStatusPage,
+						Resource:  Resource{Kind: "Incident", Name: incident.Title, ID: incident.Link.Href},
+						Message:   fmt.Sprintf("Status page: %s", incident.Title),
+						Hint:      "Follow the incident on https://status.ionos.cloud",
+					})
 				}
 			} else {
-				*issues = append(*issues, fmt.Sprintf("Status page: %s", statusResult.Status))
+				report.Issues = append(report.Issues, Issue{
+					Severity:  SeverityWarning,
+					Check:     CheckStatusPage,
+					Subsystem: SubsystemStatusPage,
+					Message:   fmt.Sprintf("Status page: %s", statusResult.Status),
+				})
 			}
 		}
 	}
+
+	return report
 }
 
-func checkIONOS(wg *sync.WaitGroup, report *Report, issues *[]string) {
-	defer wg.Done()
+func checkIONOS(ctx context.Context, timeout time.Duration) *Report {
+	report := &Report{}
 
 	client, err := newIONOSClient()
 	if err != nil {
-		return
+		report.Issues = append(report.Issues, setupIssue(SubsystemIONOS, "IONOS Cloud", err,
+			"Set IONOS_TOKEN or IONOS_USERNAME/IONOS_PASSWORD and check the other IONOS_* variables"))
+		return report
+	}
+
+	parent := ctx
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	timedOut := func() bool {
+		if ctx.Err() == nil {
+			return false
+		}
+		report.Issues = append(report.Issues, timeoutIssue(parent, SeverityCritical, SubsystemIONOS, "IONOS Cloud", timeout))
+		return true
 	}
 
-	connResult := client.CheckConnectivity()
+	connResult := client.CheckConnectivity(ctx)
+	if timedOut() {
+		return report
+	}
 	report.APICheck = &connResult
 	if !connResult.OK {
-		*issues = append(*issues, "IONOS API unreachable")
+		report.Issues = append(report.Issues, Issue{
+			Severity:  SeverityCritical,
+			Check:     CheckAPI,
+			Subsystem: SubsystemIONOS,
+			Message:   "IONOS API unreachable",
+			Hint:      connResult.Message,
+		})
 	}
 
-	authResult := client.CheckAuthentication()
+	authResult := client.CheckAuthentication(ctx)
+	if timedOut() {
+		return report
+	}
 	report.AuthCheck = &authResult
 	if !authResult.OK {
-		*issues = append(*issues, "IONOS authentication failed")
+		report.Issues = append(report.Issues, Issue{
+			Severity:  SeverityCritical,
+			Check:     CheckAuth,
+			Subsystem: SubsystemIONOS,
+			Message:   "IONOS authentication failed",
+			Hint:      "Verify IONOS_TOKEN or IONOS_USERNAME/IONOS_PASSWORD: " + authResult.Message,
+		})
 	}
 
-	datacenterStatuses, err := client.CheckDatacenters()
+	datacenterStatuses, err := client.CheckDatacenters(ctx)
+	if timedOut() {
+		return report
+	}
 	if err != nil {
-		*issues = append(*issues, fmt.Sprintf("Datacenters: %v", err))
+		report.Issues = append(report.Issues, Issue{
+			Severity:  SeverityWarning,
+			Check:     CheckDatacenters,
+			Subsystem: SubsystemIONOS,
+			Message:   fmt.Sprintf("Datacenters: %v", err),
+		})
 	} else {
 		report.Datacenters = datacenterStatuses
 		for _, status := range datacenterStatuses {
+			dc := ResourceRef{Kind: "Datacenter", Name: status.Datacenter.Properties.Name, ID: status.Datacenter.ID}
 			for _, issue := range status.Issues {
-				*issues = append(*issues, fmt.Sprintf("DC %s: %s", status.Datacenter.Properties.Name, issue))
+				report.Issues = append(report.Issues, Issue{
+					Severity:  SeverityWarning,
+					Check:     CheckDatacenters,
+					Subsystem: SubsystemIONOS,
+					Resource:  ionosResource(dc, issue),
+					Reason:    issue.Reason,
+					Message:   fmt.Sprintf("DC %s: %s", status.Datacenter.Properties.Name, issue),
+				})
 			}
 		}
 	}
 
-	clusterStatuses, err := client.CheckK8sClusters()
+	clusterStatuses, err := client.CheckK8sClusters(ctx)
+	if timedOut() {
+		return report
+	}
 	if err != nil {
-		*issues = append(*issues, fmt.Sprintf("K8s clusters: %v", err))
+		report.Issues = append(report.Issues, Issue{
+			Severity:  SeverityWarning,
+			Check:     CheckClusters,
+			Subsystem: SubsystemIONOS,
+			Message:   fmt.Sprintf("K8s clusters: %v", err),
+		})
 	} else {
 		report.Clusters = clusterStatuses
 		for _, status := range clusterStatuses {
+			cluster := ResourceRef{Kind: "K8sCluster", Name: status.Cluster.Properties.Name, ID: status.Cluster.ID}
 			for _, issue := range status.Issues {
-				*issues = append(*issues, fmt.Sprintf("Cluster %s: %s", status.Cluster.Properties.Name, issue))
+				report.Issues = append(report.Issues, Issue{
+					Severity:  SeverityWarning,
+					Check:     CheckClusters,
+					Subsystem: SubsystemIONOS,
+					Resource:  ionosResource(cluster, issue),
+					Reason:    issue.Reason,
+					Message:   fmt.Sprintf("Cluster %s: %s", status.Cluster.Properties.Name, issue),
+				})
 			}
 		}
 	}
 
-	dbaasStatus := client.CheckDBaaS()
+	dbaasStatus := client.CheckDBaaS(ctx)
+	if timedOut() {
+		return report
+	}
 	report.DBaaS = &dbaasStatus
 	for _, issue := range dbaasStatus.Issues {
-		*issues = append(*issues, fmt.Sprintf("DBaaS: %s", issue))
+		// Issues for an engine that could not be listed are attached to the engine.
+		name := issue.Cluster
+		if name == "" {
+			name = issue.Engine
+		}
+		report.Issues = append(report.Issues, Issue{
+			Severity:  SeverityWarning,
+			Check:     CheckDBaaS,
+			Subsystem: SubsystemIONOS,
+			Resource:  Resource{Kind: "DBaaS", Name: name, ID: issue.ClusterID},
+			Message:   fmt.Sprintf("DBaaS: %s", issue),
+		})
 	}
+
+	return report
+}
+
+// ionosResource attaches an issue to the server, volume or node pool it is
+// about, so that issues of different resources in one datacenter or cluster
+// are kept apart. Issues of the datacenter or cluster itself are attached to
+// it directly.
+func ionosResource(parent ResourceRef, issue ionos.ResourceIssue) Resource {
+	if issue.Kind == "" {
+		return Resource{Kind: parent.Kind, Name: parent.Name, ID: parent.ID}
+	}
+	return Resource{Kind: issue.Kind, Name: issue.Name, ID: issue.ID, Parent: parent}
 }
 
-func checkK8s(wg *sync.WaitGroup, report *Report, issues *[]string, kubeconfig, namespace string) {
-	defer wg.Done()
+func checkK8s(ctx context.Context, kubeconfig, namespace string, cfg config.KubernetesConfig, timeout time.Duration) *Report {
+	report := &Report{}
 
-	checker, err := newK8sChecker(kubeconfig)
+	checker, err := newK8sChecker(kubeconfig, k8s.Options{
+		EventLookback:    cfg.Events.Lookback,
+		RestartThreshold: cfg.Pods.RestartThreshold,
+		NotReadyAfter:    cfg.Pods.NotReadyAfter,
+	})
 	if err != nil {
-		return
+		report.Issues = append(report.Issues, setupIssue(SubsystemKubernetes, "Kubernetes", err,
+			"Pass --kubeconfig or set kubeconfig in the config file"))
+		return report
 	}
 
-	health, err := checker.CheckHealth(context.Background(), namespace)
+	parent := ctx
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	health, err := checker.CheckHealth(ctx, namespace)
+	if ctx.Err() != nil {
+		report.Issues = append(report.Issues, timeoutIssue(parent, SeverityCritical, SubsystemKubernetes, "Kubernetes", timeout))
+		return report
+	}
 	if err != nil {
-		*issues = append(*issues, fmt.Sprintf("K8s health: %v", err))
-		return
+		report.Issues = append(report.Issues, Issue{
+			Severity:  SeverityCritical,
+			Check:     CheckK8sHealth,
+			Subsystem: SubsystemKubernetes,
+			Message:   fmt.Sprintf("K8s health: %v", err),
+			Hint:      "Verify the kubeconfig and that the API server is reachable",
+		})
+		return report
 	}
 
 	report.Health = health
+	report.Issues = append(report.Issues, healthIssues(health, cfg.Events.IssueReasons)...)
+
+	return report
+}
+
+// k8sOptionalChecks maps the checks that may be forbidden on their own, see
+// k8s.CheckError, to their issue checks.
+var k8sOptionalChecks = map[string]string{
+	"statefulsets": CheckStatefulSets,
+	"daemonsets":   CheckDaemonSets,
+	"jobs":         CheckJobs,
+	"hpas":         CheckHPAs,
+	"pdbs":         CheckPDBs,
+	"endpoints":    CheckEndpoints,
+	"ingresses":    CheckIngresses,
+	"events":       CheckEvents,
+}
+
+func healthIssues(health *k8s.HealthResult, eventReasons []string) []Issue {
+	var issues []Issue
 
-	nodeIssues := len(health.Nodes.NotReady) + len(health.Nodes.Conditions)
-	podIssues := len(health.Pods.CrashLoopBackOff) + len(health.Pods.ImagePullBackOff) + len(health.Pods.Pending) + len(health.Pods.Failed)
+	addReason := func(severity Severity, check string, resource Resource, reason, message, hint string) {
+		issues = append(issues, Issue{
+			Severity:  severity,
+			Check:     check,
+			Subsystem: SubsystemKubernetes,
+			Resource:  resource,
+			Reason:    reason,
+			Message:   message,
+			Hint:      hint,
+		})
+	}
+	add := func(severity Severity, check string, resource Resource, message, hint string) {
+		addReason(severity, check, resource, "", message, hint)
+	}
 
-	if nodeIssues > 0 {
-		*issues = append(*issues, fmt.Sprintf("%d node issues", nodeIssues))
+	for _, checkErr := range health.Errors {
+		check, ok := k8sOptionalChecks[checkErr.Check]
+		if !ok {
+			check = CheckK8sHealth
+		}
+		add(SeverityWarning, check, Resource{}, fmt.Sprintf("K8s health: %v", checkErr.Err),
+			"Allow the watchdog to list these resources, e.g. in its ClusterRole")
 	}
-	if podIssues > 0 {
-		*issues = append(*issues, fmt.Sprintf("%d pod issues", podIssues))
+
+	for _, node := range health.Nodes.NotReady {
+		add(SeverityCritical, CheckNodes, Resource{Kind: "Node", Name: node},
+			fmt.Sprintf("Node %s NotReady", node), "Check the node pool state in IONOS Cloud and the kubelet on the node")
+	}
+	for _, condition := range health.Nodes.Conditions {
+		node, reason, _ := strings.Cut(condition, " ")
+		addReason(SeverityWarning, CheckNodeConditions, Resource{Kind: "Node", Name: node}, reason,
+			fmt.Sprintf("Node %s", condition), "Free resources on the node or scale up the node pool")
+	}
+
+	podClasses := []struct {
+		pods   []string
+		reason string
+		hint   string
+	}{
+		{health.Pods.CrashLoopBackOff, "CrashLoopBackOff", "Inspect container logs with kubectl logs --previous"},
+		{health.Pods.ImagePullBackOff, "ImagePullBackOff", "Verify the image name and registry credentials"},
+		{health.Pods.Pending, "Pending", "Check scheduling events and available node capacity"},
+		{health.Pods.Failed, "Failed", "Inspect pod events and container exit codes"},
+	}
+	for _, class := range podClasses {
+		for _, pod := range class.pods {
+			ns, name := splitNamespacedName(pod)
+			addReason(SeverityWarning, CheckPods, Resource{Kind: "Pod", Namespace: ns, Name: name}, class.reason,
+				fmt.Sprintf("Pod %s %s", pod, class.reason), class.hint)
+		}
+	}
+
+	for _, pod := range health.Pods.NotReady {
+		add(SeverityWarning, CheckPods, Resource{Kind: "Pod", Namespace: pod.Namespace, Name: pod.Name},
+			fmt.Sprintf("Pod %s not ready since %s", pod.Pod(), pod.Since.UTC().Format("2006-01-02 15:04 MST")),
+			"Check the readiness probe and the pod events")
 	}
-	if len(health.Deployments.Unavailable) > 0 {
-		*issues = append(*issues, fmt.Sprintf("%d deployment issues", len(health.Deployments.Unavailable)))
+	for _, pod := range health.Pods.InitFailed {
+		add(SeverityWarning, CheckPods, Resource{Kind: "Pod", Namespace: pod.Namespace, Name: pod.Name},
+			fmt.Sprintf("Pod %s init container %s failing: %s", pod.Pod(), pod.Container, terminationText(pod)),
+			"Inspect the init container logs with kubectl logs -c")
 	}
-	if len(health.PVCs.Pending) > 0 {
-		*issues = append(*issues, fmt.Sprintf("%d PVC issues", len(health.PVCs.Pending)))
+	for _, pod := range health.Pods.Terminating {
+		add(SeverityWarning, CheckPods, Resource{Kind: "Pod", Namespace: pod.Namespace, Name: pod.Name},
+			fmt.Sprintf("Pod %s stuck terminating since %s%s", pod.Pod(), pod.Since.UTC().Format("2006-01-02 15:04 MST"), onNode(pod.Node)),
+			"Check the node of the pod and the finalizers; force delete the pod only if the node is gone")
 	}
-	if len(health.Services.NoIP) > 0 {
-		*issues = append(*issues, fmt.Sprintf("%d LoadBalancer issues", len(health.Services.NoIP)))
+	for _, pod := range health.Pods.Unknown {
+		add(SeverityWarning, CheckPods, Resource{Kind: "Pod", Namespace: pod.Namespace, Name: pod.Name},
+			fmt.Sprintf("Pod %s in Unknown state%s", pod.Pod(), onNode(pod.Node)),
+			"The node likely lost contact with the control plane; check the node pool in IONOS Cloud")
+	}
+	for _, group := range health.Pods.Evicted {
+		node := group.Node
+		if node == "" {
+			node = "unknown"
+		}
+		add(SeverityWarning, CheckPods, Resource{Kind: "Node", Name: group.Node},
+			fmt.Sprintf("%d pods evicted from node %s: %s", len(group.Pods), node, strings.Join(group.Pods, ", ")),
+			"Check the node pressure conditions and pod resource requests, then delete the evicted pods")
+	}
+	for _, pod := range health.Pods.OOMKilled {
+		add(SeverityWarning, CheckPodRestarts, Resource{Kind: "Pod", Namespace: pod.Namespace, Name: pod.Name},
+			fmt.Sprintf("Pod %s container %s OOMKilled (%d restarts)", pod.Pod(), pod.Container, pod.Restarts),
+			"Raise the memory limit of the container or reduce its memory usage")
+	}
+	for _, pod := range health.Pods.Restarts {
+		add(SeverityWarning, CheckPodRestarts, Resource{Kind: "Pod", Namespace: pod.Namespace, Name: pod.Name},
+			fmt.Sprintf("Pod %s container %s restarted %d times (last: %s)", pod.Pod(), pod.Container, pod.Restarts, terminationText(pod)),
+			"Inspect container logs with kubectl logs --previous")
+	}
+
+	for _, deploy := range health.Deployments.Unavailable {
+		ns, name := splitNamespacedName(deploy)
+		add(SeverityWarning, CheckDeployments, Resource{Kind: "Deployment", Namespace: ns, Name: name},
+			fmt.Sprintf("Deployment %s unavailable", deploy), "Check the deployment rollout status and its pods")
+	}
+	for _, sts := range health.StatefulSets.Unavailable {
+		ns, name := splitNamespacedName(sts)
+		add(SeverityWarning, CheckStatefulSets, Resource{Kind: "StatefulSet", Namespace: ns, Name: name},
+			fmt.Sprintf("StatefulSet %s unavailable", sts), "Check the StatefulSet pods and their volumes")
+	}
+	for _, sts := range health.StatefulSets.RolloutStuck {
+		ns, name := splitNamespacedName(sts)
+		add(SeverityWarning, CheckStatefulSets, Resource{Kind: "StatefulSet", Namespace: ns, Name: name},
+			fmt.Sprintf("StatefulSet %s rolling update stuck", sts), "Check the updated pod with kubectl rollout status and describe")
+	}
+	for _, ds := range health.DaemonSets.Unavailable {
+		ns, name := splitNamespacedName(ds)
+		addReason(SeverityWarning, CheckDaemonSets, Resource{Kind: "DaemonSet", Namespace: ns, Name: name}, "Unavailable",
+			fmt.Sprintf("DaemonSet %s unavailable", ds), "Check the DaemonSet pods on the affected nodes")
+	}
+	for _, ds := range health.DaemonSets.Misscheduled {
+		ns, name := splitNamespacedName(ds)
+		addReason(SeverityWarning, CheckDaemonSets, Resource{Kind: "DaemonSet", Namespace: ns, Name: name}, "Misscheduled",
+			fmt.Sprintf("DaemonSet %s has misscheduled pods", ds), "Check the node selector and tolerations of the DaemonSet")
+	}
+	for _, job := range health.Jobs.Failed {
+		message := fmt.Sprintf("Job %s/%s failed", job.Namespace, job.Name)
+		if job.Reason != "" {
+			message += ": " + job.Reason
+		}
+		add(SeverityWarning, CheckJobs, Resource{Kind: "Job", Namespace: job.Namespace, Name: job.Name},
+			message, "Inspect the logs of the job pods and the backoffLimit and activeDeadlineSeconds of the job")
+	}
+	for _, cj := range health.CronJobs.Missed {
+		lastSuccess := "never succeeded"
+		if !cj.LastSuccess.IsZero() {
+			lastSuccess = "last success " + cj.LastSuccess.UTC().Format("2006-01-02 15:04 MST")
+		}
+		add(SeverityWarning, CheckCronJobs, Resource{Kind: "CronJob", Namespace: cj.Namespace, Name: cj.Name},
+			fmt.Sprintf("CronJob %s/%s missed run at %s (%s)", cj.Namespace, cj.Name, cj.ExpectedAt.UTC().Format("2006-01-02 15:04 MST"), lastSuccess),
+			"Check the recent jobs of the CronJob and its events")
 	}
-	if len(health.Certs.Expired) > 0 {
-		*issues = append(*issues, fmt.Sprintf("%d expired certificates", len(health.Certs.Expired)))
+	for _, cj := range health.CronJobs.Suspended {
+		ns, name := splitNamespacedName(cj)
+		add(SeverityInfo, CheckCronJobs, Resource{Kind: "CronJob", Namespace: ns, Name: name},
+			fmt.Sprintf("CronJob %s suspended", cj), "Resume the CronJob or delete it if it is no longer needed")
 	}
-	if len(health.Certs.Expiring) > 0 {
-		*issues = append(*issues, fmt.Sprintf("%d certificates expiring soon", len(health.Certs.Expiring)))
+	for _, hpa := range health.HPAs.ScalingInactive {
+		message := fmt.Sprintf("HPA %s/%s cannot scale", hpa.Namespace, hpa.Name)
+		if hpa.Reason != "" {
+			message += ": " + hpa.Reason
+		}
+		add(SeverityWarning, CheckHPAs, Resource{Kind: "HorizontalPodAutoscaler", Namespace: hpa.Namespace, Name: hpa.Name},
+			message, "Check that the metrics server or metrics adapter serves the metrics of the HPA")
+	}
+	for _, hpa := range health.HPAs.AtMax {
+		add(SeverityWarning, CheckHPAs, Resource{Kind: "HorizontalPodAutoscaler", Namespace: hpa.Namespace, Name: hpa.Name},
+			fmt.Sprintf("HPA %s/%s at max replicas (%d/%d)", hpa.Namespace, hpa.Name, hpa.CurrentReplicas, hpa.MaxReplicas),
+			"Raise maxReplicas or check the load; scaling out may also need more node pool capacity")
+	}
+	for _, pdb := range health.PDBs.Blocking {
+		add(SeverityWarning, CheckPDBs, Resource{Kind: "PodDisruptionBudget", Namespace: pdb.Namespace, Name: pdb.Name},
+			fmt.Sprintf("PDB %s/%s allows no disruptions (%d/%d healthy, %d desired)", pdb.Namespace, pdb.Name, pdb.CurrentHealthy, pdb.ExpectedPods, pdb.DesiredHealthy),
+			"Node drains during IONOS node pool maintenance will stall; add replicas or relax the PDB")
+	}
+	for _, pvc := range health.PVCs.Pending {
+		ns, name := splitNamespacedName(pvc)
+		add(SeverityWarning, CheckPVCs, Resource{Kind: "PersistentVolumeClaim", Namespace: ns, Name: name},
+			fmt.Sprintf("PVC %s pending", pvc), "Verify the storage class and IONOS volume quota")
+	}
+	for _, svc := range health.Services.NoIP {
+		ns, name := splitNamespacedName(svc)
+		add(SeverityWarning, CheckLoadBalancers, Resource{Kind: "Service", Namespace: ns, Name: name},
+			fmt.Sprintf("LoadBalancer %s has no IP", svc), "Check the IP block quota and service events")
+	}
+	for _, svc := range health.Endpoints.NoEndpoints {
+		ns, name := splitNamespacedName(svc)
+		add(SeverityWarning, CheckEndpoints, Resource{Kind: "Service", Namespace: ns, Name: name},
+			fmt.Sprintf("Service %s has no ready endpoints", svc), "Check that the selector matches running and ready pods")
+	}
+	for _, ing := range health.Endpoints.IngressNoBackends {
+		add(SeverityWarning, CheckIngresses, Resource{Kind: "Ingress", Namespace: ing.Namespace, Name: ing.Name},
+			fmt.Sprintf("Ingress %s/%s routes to services without ready endpoints: %s", ing.Namespace, ing.Name, strings.Join(ing.Services, ", ")),
+			"Check that the backend services exist and select ready pods")
+	}
+
+	for _, cert := range health.Certs.Expired {
+		add(SeverityCritical, CheckCertificates, Resource{Kind: "Secret", Namespace: cert.Namespace, Name: cert.Secret},
+			fmt.Sprintf("Certificate %s (%s/%s) expired", cert.Host, cert.Namespace, cert.Secret), "Renew the certificate")
+	}
+	for _, cert := range health.Certs.Expiring {
+		add(SeverityWarning, CheckCertificates, Resource{Kind: "Secret", Namespace: cert.Namespace, Name: cert.Secret},
+			fmt.Sprintf("Certificate %s (%s/%s) expires in %d days", cert.Host, cert.Namespace, cert.Secret, cert.ExpiresIn), "Renew the certificate")
+	}
+
+	for _, group := range health.Events.Groups {
+		if !matchesAny(eventReasons, group.Reason) {
+			continue
+		}
+		addReason(SeverityWarning, CheckEvents, Resource{Kind: group.Kind, Namespace: group.Namespace, Name: group.Name}, group.Reason,
+			fmt.Sprintf("Event %s on %s %s (%dx): %s", group.Reason, group.Kind, group.Object(), group.Count, group.Message),
+			"Inspect the events with kubectl describe")
+	}
+
+	return issues
+}
+
+func evictedPods(groups []k8s.EvictedPods) int {
+	count := 0
+	for _, group := range groups {
+		count += len(group.Pods)
+	}
+	return count
+}
+
+func onNode(node string) string {
+	if node == "" {
+		return ""
+	}
+	return " on node " + node
+}
+
+func terminationText(pod k8s.PodIssue) string {
+	reason := pod.Reason
+	if reason == "" {
+		reason = "terminated"
+	}
+	if pod.ExitCode != 0 {
+		return fmt.Sprintf("%s, exit code %d", reason, pod.ExitCode)
+	}
+	return reason
+}
+
+// matchesAny reports whether value matches one of the glob patterns. Unlike
+// matchPattern, an empty list matches nothing.
+func matchesAny(patterns []string, value string) bool {
+	for _, pattern := range patterns {
+		if pattern != "" && matchPattern(pattern, value) {
+			return true
+		}
 	}
+	return false
 }
@@ -3,7 +3,10 @@ package output
 import (
 	"context"
 	"errors"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/peterpisarcik/ionos-cloud-watchdog/internal/feed"
 	"github.com/peterpisarcik/ionos-cloud-watchdog/internal/ionos"
@@ -23,7 +26,7 @@ func TestRunChecks_OK(t *testing.T) {
 	})
 	defer restore()
 
-	report, err := RunChecks("", "default")
+	report, err := RunChecks(context.Background(), Options{Namespace: "default"})
 	if err != nil {
 		t.Fatalf("RunChecks returned error: %v", err)
 	}
@@ -56,14 +59,14 @@ func TestRunChecks_CriticalAggregatesIssues(t *testing.T) {
 					Name     string "json:\"name\""
 					Location string "json:\"location\""
 				}{Name: "DC1"}},
-				Issues: []string{"Server busy"},
+				Issues: []ionos.ResourceIssue{{Message: "Server busy"}},
 			}},
 			clusters: []ionos.K8sClusterStatus{{
 				Cluster: ionos.K8sCluster{Properties: struct {
 					Name       string "json:\"name\""
 					K8sVersion string "json:\"k8sVersion\""
 				}{Name: "Cluster1"}},
-				Issues: []string{"Cluster degraded", "Node pool down"},
+				Issues: []ionos.ResourceIssue{{Message: "Cluster degraded"}, {Message: "Node pool down"}},
 			}},
 		},
 		k8sHealth: &k8s.HealthResult{
@@ -81,7 +84,7 @@ func TestRunChecks_CriticalAggregatesIssues(t *testing.T) {
 	})
 	defer restore()
 
-	report, err := RunChecks("", "default")
+	report, err := RunChecks(context.Background(), Options{Namespace: "default"})
 	if err != nil {
 		t.Fatalf("RunChecks returned error: %v", err)
 	}
@@ -90,12 +93,409 @@ func TestRunChecks_CriticalAggregatesIssues(t *testing.T) {
 		t.Fatalf("expected status CRITICAL, got %s", report.Status)
 	}
 
-	assertContains(t, report.Issues, "Status page: Incident A")
-	assertContains(t, report.Issues, "IONOS authentication failed")
-	assertContains(t, report.Issues, "DC DC1: Server busy")
-	assertContains(t, report.Issues, "Cluster Cluster1: Cluster degraded")
-	assertContains(t, report.Issues, "1 node issues")
-	assertContains(t, report.Issues, "2 pod issues")
+	messages := issueMessages(report.Issues)
+	assertContains(t, messages, "Status page: Incident A")
+	assertContains(t, messages, "IONOS authentication failed")
+	assertContains(t, messages, "DC DC1: Server busy")
+	assertContains(t, messages, "Cluster Cluster1: Cluster degraded")
+	assertContains(t, messages, "Node node-1 NotReady")
+	assertContains(t, messages, "Pod ns/pod Pending")
+	assertContains(t, messages, "Pod ns/pod CrashLoopBackOff")
+}
+
+func TestRunChecks_KeysIONOSIssuesPerResource(t *testing.T) {
+	dc := ionos.DataCenter{ID: "dc-1"}
+	dc.Properties.Name = "prod"
+	restore := stubDependencies(t, &dependencyStubs{
+		feedResult: &feed.StatusResult{Status: feed.StatusOK},
+		ionosClient: &fakeIONOSClient{
+			connectivity: ionos.CheckResult{OK: true},
+			auth:         ionos.CheckResult{OK: true},
+			datacenters: []ionos.DatacenterStatus{{
+				Datacenter: dc,
+				Issues: []ionos.ResourceIssue{
+					{Kind: "Server", ID: "srv-1", Name: "web-1", Message: "Server web-1 state: ERROR"},
+					{Kind: "Server", ID: "srv-2", Name: "web-2", Message: "Server web-2 state: ERROR"},
+					{Reason: "ServersUnavailable", Message: "Failed to get servers: boom"},
+					{Reason: "VolumesUnavailable", Message: "Failed to get volumes: boom"},
+				},
+			}},
+		},
+		k8sHealth: &k8s.HealthResult{},
+	})
+	defer restore()
+
+	report, err := RunChecks(context.Background(), Options{})
+	if err != nil {
+		t.Fatalf("RunChecks returned error: %v", err)
+	}
+
+	if len(report.Issues) != 4 {
+		t.Fatalf("expected 4 issues, got %+v", report.Issues)
+	}
+	keys := map[string]bool{}
+	for _, issue := range report.Issues {
+		keys[issue.Key()] = true
+	}
+	if len(keys) != 4 {
+		t.Fatalf("expected distinct keys per resource and reason, got %v", keys)
+	}
+
+	parent := ResourceRef{Kind: "Datacenter", Name: "prod", ID: "dc-1"}
+	if got := report.Issues[0].Resource; got != (Resource{Kind: "Server", Name: "web-1", ID: "srv-1", Parent: parent}) {
+		t.Fatalf("unexpected server resource: %+v", got)
+	}
+	if got := report.Issues[3].Resource; got != (Resource{Kind: "Datacenter", Name: "prod", ID: "dc-1"}) {
+		t.Fatalf("expected datacenter-wide issues on the datacenter, got %+v", got)
+	}
+}
+
+func TestRunChecks_ReportsClientSetupErrors(t *testing.T) {
+	restore := stubDependencies(t, &dependencyStubs{
+		feedResult: &feed.StatusResult{Status: feed.StatusOK},
+		ionosErr:   errors.New("IONOS credentials not found"),
+	})
+	defer restore()
+
+	report, err := RunChecks(context.Background(), Options{})
+	if err != nil {
+		t.Fatalf("RunChecks returned error: %v", err)
+	}
+
+	if report.Status != "CRITICAL" {
+		t.Fatalf("expected CRITICAL when subsystems could not be checked, got %s", report.Status)
+	}
+	messages := issueMessages(report.Issues)
+	assertContains(t, messages, "IONOS Cloud check could not run: IONOS credentials not found")
+	assertContains(t, messages, "Kubernetes check could not run: missing k8s stub")
+	for _, issue := range report.Issues {
+		if issue.Check != CheckSetup {
+			t.Fatalf("expected only setup issues, got %+v", issue)
+		}
+	}
+
+	setupErr := report.SetupError()
+	if setupErr == nil || !strings.Contains(setupErr.Error(), "IONOS credentials not found") || !strings.Contains(setupErr.Error(), "missing k8s stub") {
+		t.Fatalf("expected both subsystems in the setup error, got %v", setupErr)
+	}
+	if err := (&Report{Issues: []Issue{{Check: CheckPods, Message: "Pod ns/a Pending"}}}).SetupError(); err != nil {
+		t.Fatalf("expected no setup error for a report without setup issues, got %v", err)
+	}
+}
+
+func TestRunChecks_StatusFollowsWorstSeverity(t *testing.T) {
+	restore := stubDependencies(t, &dependencyStubs{
+		feedResult: &feed.StatusResult{Status: feed.StatusOK},
+		ionosClient: &fakeIONOSClient{
+			connectivity: ionos.CheckResult{OK: true},
+			auth:         ionos.CheckResult{OK: true},
+		},
+		k8sHealth: &k8s.HealthResult{
+			Pods: k8s.PodResult{
+				Total:   4,
+				Pending: []string{"ns/a", "ns/b", "ns/c", "ns/d"},
+			},
+		},
+	})
+	defer restore()
+
+	report, err := RunChecks(context.Background(), Options{Namespace: "default"})
+	if err != nil {
+		t.Fatalf("RunChecks returned error: %v", err)
+	}
+
+	if report.Status != "WARNING" {
+		t.Fatalf("expected status WARNING for pending pods only, got %s", report.Status)
+	}
+	if len(report.Issues) != 4 {
+		t.Fatalf("expected 4 issues, got %d", len(report.Issues))
+	}
+	issue := report.Issues[0]
+	if issue.Severity != SeverityWarning || issue.Check != CheckPods || issue.Subsystem != SubsystemKubernetes {
+		t.Fatalf("unexpected issue classification: %+v", issue)
+	}
+	if issue.Resource.Kind != "Pod" || issue.Resource.Namespace != "ns" || issue.Resource.Name != "a" {
+		t.Fatalf("unexpected issue resource: %+v", issue.Resource)
+	}
+}
+
+func TestHealthIssues_Certificates(t *testing.T) {
+	issues := healthIssues(&k8s.HealthResult{
+		Certs: k8s.CertResult{
+			Expired:  []k8s.CertInfo{{Host: "old.example.com", Namespace: "web", Secret: "tls-old", ExpiresIn: -1}},
+			Expiring: []k8s.CertInfo{{Host: "soon.example.com", Namespace: "web", Secret: "tls-soon", ExpiresIn: 5}},
+		},
+	}, nil)
+
+	if len(issues) != 2 {
+		t.Fatalf("expected 2 issues, got %d", len(issues))
+	}
+	if issues[0].Severity != SeverityCritical || issues[0].Resource.Name != "tls-old" {
+		t.Fatalf("expected expired cert to be critical: %+v", issues[0])
+	}
+	if issues[1].Severity != SeverityWarning || issues[1].Message != "Certificate soon.example.com (web/tls-soon) expires in 5 days" {
+		t.Fatalf("unexpected expiring cert issue: %+v", issues[1])
+	}
+}
+
+func TestHealthIssues_NodeConditionsKeyedPerCondition(t *testing.T) {
+	issues := healthIssues(&k8s.HealthResult{
+		Nodes: k8s.NodeResult{Conditions: []string{"node-2 MemoryPressure", "node-2 DiskPressure"}},
+	}, nil)
+
+	if len(issues) != 2 {
+		t.Fatalf("expected 2 issues, got %+v", issues)
+	}
+	if issues[0].Resource != (Resource{Kind: "Node", Name: "node-2"}) || issues[0].Reason != "MemoryPressure" || issues[1].Reason != "DiskPressure" {
+		t.Fatalf("unexpected node condition issues: %+v", issues)
+	}
+	if issues[0].Key() == issues[1].Key() {
+		t.Fatalf("expected distinct keys per condition, got %q", issues[0].Key())
+	}
+}
+
+func TestHealthIssues_Workloads(t *testing.T) {
+	issues := healthIssues(&k8s.HealthResult{
+		StatefulSets: k8s.StatefulSetResult{Unavailable: []string{"db/redis"}, RolloutStuck: []string{"db/kafka"}},
+		DaemonSets:   k8s.DaemonSetResult{Unavailable: []string{"logging/node-exporter"}, Misscheduled: []string{"logging/promtail"}},
+	}, nil)
+
+	want := []struct {
+		check   string
+		kind    string
+		message string
+	}{
+		{CheckStatefulSets, "StatefulSet", "StatefulSet db/redis unavailable"},
+		{CheckStatefulSets, "StatefulSet", "StatefulSet db/kafka rolling update stuck"},
+		{CheckDaemonSets, "DaemonSet", "DaemonSet logging/node-exporter unavailable"},
+		{CheckDaemonSets, "DaemonSet", "DaemonSet logging/promtail has misscheduled pods"},
+	}
+	if len(issues) != len(want) {
+		t.Fatalf("expected %d issues, got %+v", len(want), issues)
+	}
+	for i, w := range want {
+		if issues[i].Check != w.check || issues[i].Resource.Kind != w.kind || issues[i].Message != w.message {
+			t.Errorf("issue %d: expected %+v, got %+v", i, w, issues[i])
+		}
+	}
+}
+
+func TestHealthIssues_Jobs(t *testing.T) {
+	expected := time.Date(2025, 1, 15, 2, 0, 0, 0, time.UTC)
+	issues := healthIssues(&k8s.HealthResult{
+		Jobs: k8s.JobResult{Failed: []k8s.FailedJob{{Namespace: "ops", Name: "backup-1", Reason: "BackoffLimitExceeded", CronJob: "backup"}}},
+		CronJobs: k8s.CronJobResult{
+			Missed: []k8s.MissedCronJob{
+				{Namespace: "ops", Name: "backup", ExpectedAt: expected, LastSuccess: expected.Add(-24 * time.Hour)},
+				{Namespace: "ops", Name: "report", ExpectedAt: expected},
+			},
+			Suspended: []string{"ops/cleanup"},
+		},
+	}, nil)
+
+	want := []struct {
+		severity Severity
+		check    string
+		message  string
+	}{
+		{SeverityWarning, CheckJobs, "Job ops/backup-1 failed: BackoffLimitExceeded"},
+		{SeverityWarning, CheckCronJobs, "CronJob ops/backup missed run at 2025-01-15 02:00 UTC (last success 2025-01-14 02:00 UTC)"},
+		{SeverityWarning, CheckCronJobs, "CronJob ops/report missed run at 2025-01-15 02:00 UTC (never succeeded)"},
+		{SeverityInfo, CheckCronJobs, "CronJob ops/cleanup suspended"},
+	}
+	if len(issues) != len(want) {
+		t.Fatalf("expected %d issues, got %+v", len(want), issues)
+	}
+	for i, w := range want {
+		if issues[i].Severity != w.severity || issues[i].Check != w.check || issues[i].Message != w.message {
+			t.Errorf("issue %d: expected %+v, got %+v", i, w, issues[i])
+		}
+	}
+}
+
+func TestHealthIssues_PodCategories(t *testing.T) {
+	since := time.Date(2025, 1, 15, 10, 4, 0, 0, time.UTC)
+	issues := healthIssues(&k8s.HealthResult{
+		Pods: k8s.PodResult{
+			NotReady:   []k8s.PodIssue{{Namespace: "web", Name: "api-1", Reason: "NotReady", Since: since}},
+			InitFailed: []k8s.PodIssue{{Namespace: "web", Name: "api-2", Container: "migrate", Reason: "CrashLoopBackOff", ExitCode: 1}},
+			OOMKilled:  []k8s.PodIssue{{Namespace: "web", Name: "api-3", Container: "app", Reason: "OOMKilled", ExitCode: 137, Restarts: 2}},
+			Restarts:   []k8s.PodIssue{{Namespace: "web", Name: "api-4", Container: "app", Reason: "Error", ExitCode: 2, Restarts: 9}},
+		},
+	}, nil)
+
+	want := []struct {
+		check   string
+		message string
+	}{
+		{CheckPods, "Pod web/api-1 not ready since 2025-01-15 10:04 UTC"},
+		{CheckPods, "Pod web/api-2 init container migrate failing: CrashLoopBackOff, exit code 1"},
+		{CheckPodRestarts, "Pod web/api-3 container app OOMKilled (2 restarts)"},
+		{CheckPodRestarts, "Pod web/api-4 container app restarted 9 times (last: Error, exit code 2)"},
+	}
+	if len(issues) != len(want) {
+		t.Fatalf("expected %d issues, got %+v", len(want), issues)
+	}
+	for i, w := range want {
+		if issues[i].Check != w.check || issues[i].Message != w.message || issues[i].Resource.Kind != "Pod" {
+			t.Errorf("issue %d: expected %+v, got %+v", i, w, issues[i])
+		}
+	}
+}
+
+func TestHealthIssues_PodStates(t *testing.T) {
+	issues := healthIssues(&k8s.HealthResult{
+		Pods: k8s.PodResult{
+			Terminating: []k8s.PodIssue{{Namespace: "web", Name: "api-1", Node: "node-1", Since: time.Date(2025, 1, 15, 10, 4, 0, 0, time.UTC)}},
+			Unknown:     []k8s.PodIssue{{Namespace: "web", Name: "api-2", Node: "node-3"}},
+			Evicted:     []k8s.EvictedPods{{Node: "node-2", Pods: []string{"web/a", "web/b"}}},
+		},
+	}, nil)
+
+	want := []struct {
+		resource Resource
+		message  string
+	}{
+		{Resource{Kind: "Pod", Namespace: "web", Name: "api-1"}, "Pod web/api-1 stuck terminating since 2025-01-15 10:04 UTC on node node-1"},
+		{Resource{Kind: "Pod", Namespace: "web", Name: "api-2"}, "Pod web/api-2 in Unknown state on node node-3"},
+		{Resource{Kind: "Node", Name: "node-2"}, "2 pods evicted from node node-2: web/a, web/b"},
+	}
+	if len(issues) != len(want) {
+		t.Fatalf("expected %d issues, got %+v", len(want), issues)
+	}
+	for i, w := range want {
+		if issues[i].Check != CheckPods || issues[i].Resource != w.resource || issues[i].Message != w.message {
+			t.Errorf("issue %d: expected %+v, got %+v", i, w, issues[i])
+		}
+	}
+}
+
+func TestHealthIssues_HPAsAndPDBs(t *testing.T) {
+	issues := healthIssues(&k8s.HealthResult{
+		HPAs: k8s.HPAResult{
+			ScalingInactive: []k8s.HPAIssue{{Namespace: "web", Name: "worker", CurrentReplicas: 2, MaxReplicas: 10, Reason: "FailedGetResourceMetric"}},
+			AtMax:           []k8s.HPAIssue{{Namespace: "web", Name: "frontend", CurrentReplicas: 10, MaxReplicas: 10}},
+		},
+		PDBs: k8s.PDBResult{
+			Blocking: []k8s.PDBIssue{{Namespace: "db", Name: "postgres", CurrentHealthy: 1, DesiredHealthy: 1, ExpectedPods: 1}},
+		},
+	}, nil)
+
+	want := []struct {
+		check   string
+		kind    string
+		message string
+	}{
+		{CheckHPAs, "HorizontalPodAutoscaler", "HPA web/worker cannot scale: FailedGetResourceMetric"},
+		{CheckHPAs, "HorizontalPodAutoscaler", "HPA web/frontend at max replicas (10/10)"},
+		{CheckPDBs, "PodDisruptionBudget", "PDB db/postgres allows no disruptions (1/1 healthy, 1 desired)"},
+	}
+	if len(issues) != len(want) {
+		t.Fatalf("expected %d issues, got %+v", len(want), issues)
+	}
+	for i, w := range want {
+		if issues[i].Check != w.check || issues[i].Resource.Kind != w.kind || issues[i].Message != w.message {
+			t.Errorf("issue %d: expected %+v, got %+v", i, w, issues[i])
+		}
+	}
+}
+
+func TestHealthIssues_Endpoints(t *testing.T) {
+	issues := healthIssues(&k8s.HealthResult{
+		Endpoints: k8s.EndpointResult{
+			Total:             2,
+			Ready:             1,
+			NoEndpoints:       []string{"web/api"},
+			Ingresses:         1,
+			IngressNoBackends: []k8s.IngressIssue{{Namespace: "web", Name: "public", Services: []string{"api", "legacy"}}},
+		},
+	}, nil)
+
+	want := []struct {
+		check   string
+		kind    string
+		message string
+	}{
+		{CheckEndpoints, "Service", "Service web/api has no ready endpoints"},
+		{CheckIngresses, "Ingress", "Ingress web/public routes to services without ready endpoints: api, legacy"},
+	}
+	if len(issues) != len(want) {
+		t.Fatalf("expected %d issues, got %+v", len(want), issues)
+	}
+	for i, w := range want {
+		if issues[i].Check != w.check || issues[i].Resource.Kind != w.kind || issues[i].Message != w.message {
+			t.Errorf("issue %d: expected %+v, got %+v", i, w, issues[i])
+		}
+	}
+}
+
+func TestHealthIssues_CheckErrors(t *testing.T) {
+	issues := healthIssues(&k8s.HealthResult{
+		Errors: []k8s.CheckError{
+			{Check: "statefulsets", Err: errors.New("failed to check statefulsets: forbidden")},
+			{Check: "unknown", Err: errors.New("failed to check unknown: boom")},
+		},
+	}, nil)
+
+	if len(issues) != 2 {
+		t.Fatalf("expected 2 issues, got %+v", issues)
+	}
+	if issues[0].Check != CheckStatefulSets || issues[0].Severity != SeverityWarning || issues[0].Message != "K8s health: failed to check statefulsets: forbidden" {
+		t.Fatalf("unexpected issue: %+v", issues[0])
+	}
+	if issues[1].Check != CheckK8sHealth {
+		t.Fatalf("expected unknown checks to fall back to %s, got %+v", CheckK8sHealth, issues[1])
+	}
+}
+
+func TestHealthIssues_EventReasons(t *testing.T) {
+	health := &k8s.HealthResult{
+		Events: k8s.EventResult{Groups: []k8s.EventGroup{
+			{Reason: "FailedMount", Kind: "Pod", Namespace: "web", Name: "db-0", Message: "timed out waiting for volume", Count: 5},
+			{Reason: "BackOff", Kind: "Pod", Namespace: "web", Name: "api", Message: "Back-off restarting failed container", Count: 12},
+			{Reason: "FailedScheduling", Kind: "Pod", Namespace: "web", Name: "worker", Message: "0/3 nodes are available", Count: 1},
+		}},
+	}
+
+	if issues := healthIssues(health, nil); len(issues) != 0 {
+		t.Fatalf("expected no event issues without configured reasons, got %+v", issues)
+	}
+
+	issues := healthIssues(health, []string{"FailedMount", "Back*"})
+	if len(issues) != 2 {
+		t.Fatalf("expected 2 issues, got %+v", issues)
+	}
+	if issues[0].Check != CheckEvents || issues[0].Severity != SeverityWarning {
+		t.Fatalf("unexpected issue classification: %+v", issues[0])
+	}
+	if issues[0].Resource != (Resource{Kind: "Pod", Namespace: "web", Name: "db-0"}) {
+		t.Fatalf("unexpected issue resource: %+v", issues[0].Resource)
+	}
+	if issues[0].Message != "Event FailedMount on Pod web/db-0 (5x): timed out waiting for volume" {
+		t.Fatalf("unexpected message: %q", issues[0].Message)
+	}
+	if issues[1].Resource.Name != "api" {
+		t.Fatalf("expected glob to match BackOff: %+v", issues[1])
+	}
+}
+
+func TestHealthIssues_EventReasonsOfOneObjectKeptApart(t *testing.T) {
+	issues := healthIssues(&k8s.HealthResult{
+		Events: k8s.EventResult{Groups: []k8s.EventGroup{
+			{Reason: "FailedMount", Kind: "Pod", Namespace: "web", Name: "db-0", Message: "timed out waiting for volume", Count: 5},
+			{Reason: "BackOff", Kind: "Pod", Namespace: "web", Name: "db-0", Message: "Back-off restarting failed container", Count: 3},
+		}},
+	}, []string{"FailedMount", "BackOff"})
+
+	if len(issues) != 2 {
+		t.Fatalf("expected 2 issues, got %+v", issues)
+	}
+	if issues[0].Reason != "FailedMount" || issues[1].Reason != "BackOff" {
+		t.Fatalf("expected the event reasons on the issues, got %+v", issues)
+	}
+	if issues[0].Key() == issues[1].Key() {
+		t.Fatalf("expected distinct keys per event reason, got %q", issues[0].Key())
+	}
 }
 
 type dependencyStubs struct {
@@ -114,13 +514,13 @@ func stubDependencies(t *testing.T, stubs *dependencyStubs) func() {
 	origIONOS := newIONOSClient
 	origK8s := newK8sChecker
 
-	feedCheckStatus = func() (*feed.StatusResult, error) {
+	feedCheckStatus = func(_ context.Context) (*feed.StatusResult, error) {
 		return stubs.feedResult, stubs.feedErr
 	}
 	newIONOSClient = func() (ionosClient, error) {
 		return stubs.ionosClient, stubs.ionosErr
 	}
-	newK8sChecker = func(_ string) (k8sChecker, error) {
+	newK8sChecker = func(_ string, _ k8s.Options) (k8sChecker, error) {
 		if stubs.k8sHealth == nil && stubs.k8sErr == nil {
 			return nil, errors.New("missing k8s stub")
 		}
@@ -143,23 +543,23 @@ type fakeIONOSClient struct {
 	err          error
 }
 
-func (f *fakeIONOSClient) CheckConnectivity() ionos.CheckResult {
+func (f *fakeIONOSClient) CheckConnectivity(_ context.Context) ionos.CheckResult {
 	return f.connectivity
 }
 
-func (f *fakeIONOSClient) CheckAuthentication() ionos.CheckResult {
+func (f *fakeIONOSClient) CheckAuthentication(_ context.Context) ionos.CheckResult {
 	return f.auth
 }
 
-func (f *fakeIONOSClient) CheckDatacenters() ([]ionos.DatacenterStatus, error) {
+func (f *fakeIONOSClient) CheckDatacenters(_ context.Context) ([]ionos.DatacenterStatus, error) {
 	return f.datacenters, f.err
 }
 
-func (f *fakeIONOSClient) CheckK8sClusters() ([]ionos.K8sClusterStatus, error) {
+func (f *fakeIONOSClient) CheckK8sClusters(_ context.Context) ([]ionos.K8sClusterStatus, error) {
 	return f.clusters, f.err
 }
 
-func (f *fakeIONOSClient) CheckDBaaS() ionos.DBaaSStatus {
+func (f *fakeIONOSClient) CheckDBaaS(_ context.Context) ionos.DBaaSStatus {
 	return f.dbaas
 }
 
@@ -172,6 +572,14 @@ func (f *fakeK8sChecker) CheckHealth(ctx context.Context, namespace string) (*k8
 	return f.health, f.err
 }
 
+func issueMessages(issues []Issue) []string {
+	messages := make([]string, 0, len(issues))
+	for _, issue := range issues {
+		messages = append(messages, issue.Message)
+	}
+	return messages
+}
+
 func assertContains(t *testing.T, list []string, expected string) {
 	t.Helper()
 	for _, item := range list {
@@ -181,3 +589,141 @@ func assertContains(t *testing.T, list []string, expected string) {
 	}
 	t.Fatalf("expected %q in %v", expected, list)
 }
+
+func TestRunChecks_ReportsTimedOutChecks(t *testing.T) {
+	restore := stubDependencies(t, &dependencyStubs{
+		feedResult: &feed.StatusResult{Status: feed.StatusOK},
+		ionosClient: &fakeIONOSClient{
+			connectivity: ionos.CheckResult{OK: true},
+			auth:         ionos.CheckResult{OK: true},
+		},
+		k8sHealth: &k8s.HealthResult{},
+	})
+	defer restore()
+	newK8sChecker = func(_ string, _ k8s.Options) (k8sChecker, error) { return blockingK8sChecker{}, nil }
+
+	report, err := RunChecks(context.Background(), Options{
+		Timeouts: Timeouts{Kubernetes: 20 * time.Millisecond},
+	})
+	if err != nil {
+		t.Fatalf("RunChecks returned error: %v", err)
+	}
+
+	if report.Health != nil {
+		t.Fatalf("expected no health result for timed out check")
+	}
+	if len(report.Issues) != 1 {
+		t.Fatalf("expected a single timeout issue, got %v", report.Issues)
+	}
+	issue := report.Issues[0]
+	if issue.Check != CheckTimeout || issue.Subsystem != SubsystemKubernetes {
+		t.Fatalf("unexpected timeout issue: %+v", issue)
+	}
+	if issue.Message != "Kubernetes check timed out after 20ms" {
+		t.Fatalf("unexpected message: %s", issue.Message)
+	}
+}
+
+func TestRunChecks_ReportsOverallDeadline(t *testing.T) {
+	restore := stubDependencies(t, &dependencyStubs{
+		feedResult: &feed.StatusResult{Status: feed.StatusOK},
+		ionosClient: &fakeIONOSClient{
+			connectivity: ionos.CheckResult{OK: true},
+			auth:         ionos.CheckResult{OK: true},
+		},
+	})
+	defer restore()
+	newK8sChecker = func(_ string, _ k8s.Options) (k8sChecker, error) { return blockingK8sChecker{}, nil }
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	report, err := RunChecks(ctx, Options{})
+	if err != nil {
+		t.Fatalf("RunChecks returned error: %v", err)
+	}
+
+	if len(report.Issues) != 1 {
+		t.Fatalf("expected a single timeout issue, got %v", report.Issues)
+	}
+	issue := report.Issues[0]
+	if issue.Check != CheckTimeout || issue.Subsystem != SubsystemKubernetes {
+		t.Fatalf("unexpected timeout issue: %+v", issue)
+	}
+	if issue.Message != "Kubernetes check stopped by the overall timeout of the run" {
+		t.Fatalf("expected the overall deadline instead of the Kubernetes timeout, got %q", issue.Message)
+	}
+}
+
+func TestRunChecks_CancelledContextReturnsError(t *testing.T) {
+	restore := stubDependencies(t, &dependencyStubs{
+		feedResult:  &feed.StatusResult{Status: feed.StatusOK},
+		ionosClient: &fakeIONOSClient{},
+		k8sHealth:   &k8s.HealthResult{},
+	})
+	defer restore()
+	newK8sChecker = func(_ string, _ k8s.Options) (k8sChecker, error) { return blockingK8sChecker{}, nil }
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := RunChecks(ctx, Options{}); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got: %v", err)
+	}
+}
+
+type blockingK8sChecker struct{}
+
+func (blockingK8sChecker) CheckHealth(ctx context.Context, _ string) (*k8s.HealthResult, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestRunChecks_MergesIssuesInSubsystemOrder(t *testing.T) {
+	restore := stubDependencies(t, &dependencyStubs{
+		feedResult: &feed.StatusResult{
+			Status:          feed.StatusWarning,
+			ActiveIncidents: []feed.Entry{{Title: "Incident A"}},
+		},
+		ionosClient: &fakeIONOSClient{
+			connectivity: ionos.CheckResult{OK: true},
+			auth:         ionos.CheckResult{OK: false},
+		},
+		k8sHealth: &k8s.HealthResult{
+			Nodes: k8s.NodeResult{Total: 1, NotReady: []string{"node-1"}},
+		},
+	})
+	defer restore()
+
+	expected := []string{SubsystemStatusPage, SubsystemIONOS, SubsystemKubernetes}
+
+	// Run several reports concurrently so `go test -race` catches shared writes.
+	const runs = 20
+	reports := make([]*Report, runs)
+	errs := make([]error, runs)
+	var wg sync.WaitGroup
+	for i := range runs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			reports[i], errs[i] = RunChecks(context.Background(), Options{})
+		}()
+	}
+	wg.Wait()
+
+	for i, report := range reports {
+		if errs[i] != nil {
+			t.Fatalf("RunChecks returned error: %v", errs[i])
+		}
+		if len(report.Issues) != len(expected) {
+			t.Fatalf("expected %d issues, got %v", len(expected), report.Issues)
+		}
+		for j, issue := range report.Issues {
+			if issue.Subsystem != expected[j] {
+				t.Fatalf("run %d: expected issue %d from %s, got %+v", i, j, expected[j], issue)
+			}
+		}
+		if report.StatusPage == nil || report.AuthCheck == nil || report.Health == nil {
+			t.Fatalf("run %d: expected all subsystem results to be merged", i)
+		}
+	}
+}
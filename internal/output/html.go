@@ -0,0 +1,69 @@
+package output
+
+import (
+	_ "embed"
+	"html/template"
+	"io"
+	"strings"
+)
+
+//go:embed report.html.tmpl
+var htmlTemplateText string
+
+var htmlTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"stateClass":  stateClass,
+	"okClass":     okClass,
+	"issuesClass": issuesClass,
+	"resources": func(title string, items []JSONResourceState) any {
+		return struct {
+			Title string
+			Items []JSONResourceState
+		}{title, items}
+	},
+	"list": func(title, class string, items []string) any {
+		return struct {
+			Title, Class string
+			Items        []string
+		}{title, class, items}
+	},
+	"pods": func(title, class string, items []JSONPodIssue) any {
+		return struct {
+			Title, Class string
+			Items        []JSONPodIssue
+		}{title, class, items}
+	},
+}).Parse(htmlTemplateText))
+
+// WriteHTML renders the report as a self-contained HTML page, based on the
+// same model as the JSON output.
+func WriteHTML(w io.Writer, report *Report) error {
+	return htmlTemplate.Execute(w, NewJSONReport(report))
+}
+
+// stateClass maps an overall status or IONOS resource state to a colour.
+func stateClass(state string) string {
+	switch state := strings.ToUpper(state); {
+	case state == "OK" || state == "AVAILABLE" || state == "ACTIVE" || state == "RUNNING":
+		return "ok"
+	case state == "CRITICAL" || strings.Contains(state, "FAIL") || strings.Contains(state, "ERROR"):
+		return "critical"
+	case state == "SHUTOFF" || state == "INACTIVE" || state == "SUSPENDED":
+		return "info"
+	default:
+		return "warning"
+	}
+}
+
+func okClass(ok bool) string {
+	if ok {
+		return "ok"
+	}
+	return "critical"
+}
+
+func issuesClass(issues []string) string {
+	if len(issues) > 0 {
+		return "warning"
+	}
+	return "ok"
+}
@@ -0,0 +1,53 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/peterpisarcik/ionos-cloud-watchdog/internal/k8s"
+)
+
+func TestWriteHTML(t *testing.T) {
+	report := serviceReport()
+	report.Issues = append(report.Issues, Issue{Severity: SeverityWarning, Check: CheckPods, Subsystem: SubsystemKubernetes, Message: "Pod <script>alert(1)</script> Pending"})
+	report.Health.Pods.OOMKilled = []k8s.PodIssue{{Namespace: "web", Name: "api-1", Container: "app", Reason: "OOMKilled", ExitCode: 137, Restarts: 4}}
+
+	var buf bytes.Buffer
+	if err := WriteHTML(&buf, report); err != nil {
+		t.Fatalf("WriteHTML returned error: %v", err)
+	}
+	out := buf.String()
+
+	for _, want := range []string{
+		"<title>IONOS Cloud Watchdog: CRITICAL</title>",
+		`<span class="badge critical">critical</span>`,
+		"<summary>Datacenters (1)</summary>",
+		`prod <span class="muted">de/fra</span> <span class="badge warning">ISSUES</span>`,
+		"<summary>Servers (2)</summary>",
+		`<td>postgresql</td><td>orders</td><td>15</td><td>de/fra</td><td><span class="badge warning">BUSY</span></td>`,
+		"<tr><th>Nodes</th><td>2/3 Ready</td></tr>",
+		"<li>node-3</li>",
+		"Pod &lt;script&gt;alert(1)&lt;/script&gt; Pending",
+		"<tr><td>web/api-1</td><td></td><td>app</td><td>OOMKilled</td><td>137</td><td>4</td><td></td></tr>",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in output:\n%s", want, out)
+		}
+	}
+}
+
+func TestStateClass(t *testing.T) {
+	for state, want := range map[string]string{
+		"AVAILABLE": "ok",
+		"RUNNING":   "ok",
+		"BUSY":      "warning",
+		"FAILED":    "critical",
+		"SHUTOFF":   "info",
+		"WARNING":   "warning",
+	} {
+		if got := stateClass(state); got != want {
+			t.Fatalf("stateClass(%q) = %q, want %q", state, got, want)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package output
+
+import (
+	"strings"
+)
+
+type Severity string
+
+const (
+	SeverityInfo     Severity = "info"
+	SeverityWarning  Severity = "warning"
+	SeverityCritical Severity = "critical"
+)
+
+const (
+	SubsystemStatusPage = "statuspage"
+	SubsystemIONOS      = "ionos"
+	SubsystemKubernetes = "kubernetes"
+)
+
+const (
+	CheckStatusPage     = "statuspage.incidents"
+	CheckAPI            = "ionos.api"
+	CheckAuth           = "ionos.auth"
+	CheckDatacenters    = "ionos.datacenters"
+	CheckClusters       = "ionos.k8s_clusters"
+	CheckDBaaS          = "ionos.dbaas"
+	CheckK8sHealth      = "k8s.health"
+	CheckNodes          = "k8s.nodes"
+	CheckNodeConditions = "k8s.node_conditions"
+	CheckPods           = "k8s.pods"
+	CheckPodRestarts    = "k8s.pod_restarts"
+	CheckDeployments    = "k8s.deployments"
+	CheckStatefulSets   = "k8s.statefulsets"
+	CheckDaemonSets     = "k8s.daemonsets"
+	CheckJobs           = "k8s.jobs"
+	CheckCronJobs       = "k8s.cronjobs"
+	CheckHPAs           = "k8s.hpas"
+	CheckPDBs           = "k8s.pdbs"
+	CheckPVCs           = "k8s.pvcs"
+	CheckLoadBalancers  = "k8s.loadbalancers"
+	CheckEndpoints      = "k8s.endpoints"
+	CheckIngresses      = "k8s.ingresses"
+	CheckCertificates   = "k8s.certificates"
+	CheckEvents         = "k8s.events"
+	CheckTimeout        = "timeout"
+	CheckSetup          = "setup"
+)
+
+type Resource struct {
+	Kind      string
+	Name      string
+	ID        string
+	Namespace string
+	// Parent is the datacenter or cluster a server, volume, NIC, LAN or node
+	// pool belongs to.
+	Parent ResourceRef
+}
+
+type ResourceRef struct {
+	Kind string
+	Name string
+	ID   string
+}
+
+type Issue struct {
+	Severity  Severity
+	Check     string
+	Subsystem string
+	Resource  Resource
+	// Reason tells apart issues of one resource in the same check, e.g. the
+	// node condition or the event reason.
+	Reason  string
+	Message string
+	Hint    string
+}
+
+func (i Issue) String() string {
+	return i.Message
+}
+
+// Key identifies an issue across check runs. Issues attached to a resource are
+// keyed by check and resource so that changing details in the message do not
+// turn them into new issues, and by reason if one resource can have several.
+func (i Issue) Key() string {
+	if i.Resource == (Resource{}) {
+		return i.Check + "|" + i.Message
+	}
+	key := strings.Join([]string{i.Check, i.Resource.Kind, i.Resource.Namespace, i.Resource.Name, i.Resource.ID}, "|")
+	if i.Reason != "" {
+		key += "|" + i.Reason
+	}
+	return key
+}
+
+func (s Severity) rank() int {
+	switch s {
+	case SeverityCritical:
+		return 2
+	case SeverityWarning:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func worstSeverity(issues []Issue) Severity {
+	worst := SeverityInfo
+	for _, issue := range issues {
+		if issue.Severity.rank() > worst.rank() {
+			worst = issue.Severity
+		}
+	}
+	return worst
+}
+
+func statusFromIssues(issues []Issue) string {
+	switch worstSeverity(issues) {
+	case SeverityCritical:
+		return "CRITICAL"
+	case SeverityWarning:
+		return "WARNING"
+	default:
+		return "OK"
+	}
+}
+
+// splitNamespacedName splits "namespace/name" as produced by the k8s checker.
+func splitNamespacedName(s string) (string, string) {
+	if ns, name, ok := strings.Cut(s, "/"); ok {
+		return ns, name
+	}
+	return "", s
+}
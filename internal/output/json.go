@@ -0,0 +1,725 @@
+package output
+
+import (
+	_ "embed"
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/peterpisarcik/ionos-cloud-watchdog/internal/ionos"
+	"github.com/peterpisarcik/ionos-cloud-watchdog/internal/k8s"
+)
+
+// SchemaVersion is bumped whenever a field of the JSON output is renamed,
+// removed or changes its meaning. Adding fields does not change it.
+const SchemaVersion = "1"
+
+//go:embed report.schema.json
+var schema []byte
+
+func Schema() []byte {
+	return schema
+}
+
+type JSONReport struct {
+	SchemaVersion   string          `json:"schema_version"`
+	Status          string          `json:"status"`
+	StartedAt       time.Time       `json:"started_at"`
+	FinishedAt      time.Time       `json:"finished_at"`
+	DurationSeconds float64         `json:"duration_seconds"`
+	Summary         JSONSummary     `json:"summary"`
+	StatusPage      *JSONStatusPage `json:"status_page,omitempty"`
+	IONOS           *JSONIONOS      `json:"ionos,omitempty"`
+	Kubernetes      *JSONKubernetes `json:"kubernetes,omitempty"`
+	Issues          []JSONIssue     `json:"issues"`
+}
+
+type JSONSummary struct {
+	Issues   int `json:"issues"`
+	Critical int `json:"critical"`
+	Warning  int `json:"warning"`
+	Info     int `json:"info"`
+}
+
+type JSONStatusPage struct {
+	Status    string         `json:"status"`
+	Incidents []JSONIncident `json:"incidents"`
+}
+
+type JSONIncident struct {
+	Title   string `json:"title"`
+	Link    string `json:"link,omitempty"`
+	Updated string `json:"updated,omitempty"`
+}
+
+type JSONCheck struct {
+	OK      bool   `json:"ok"`
+	Message string `json:"message"`
+}
+
+type JSONIONOS struct {
+	API         *JSONCheck       `json:"api,omitempty"`
+	Auth        *JSONCheck       `json:"auth,omitempty"`
+	Datacenters []JSONDatacenter `json:"datacenters"`
+	K8sClusters []JSONK8sCluster `json:"k8s_clusters"`
+	DBaaS       *JSONDBaaS       `json:"dbaas,omitempty"`
+}
+
+type JSONResourceState struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	State string `json:"state"`
+}
+
+type JSONDatacenter struct {
+	ID       string              `json:"id"`
+	Name     string              `json:"name"`
+	Location string              `json:"location"`
+	Servers  []JSONResourceState `json:"servers"`
+	Volumes  []JSONResourceState `json:"volumes"`
+	NICs     []JSONResourceState `json:"nics,omitempty"`
+	LANs     []JSONResourceState `json:"lans,omitempty"`
+	Issues   []string            `json:"issues"`
+}
+
+type JSONK8sCluster struct {
+	ID        string         `json:"id"`
+	Name      string         `json:"name"`
+	Version   string         `json:"version"`
+	State     string         `json:"state"`
+	NodePools []JSONNodePool `json:"node_pools"`
+	Issues    []string       `json:"issues"`
+}
+
+type JSONNodePool struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Version   string `json:"version"`
+	State     string `json:"state"`
+	NodeCount int    `json:"node_count"`
+}
+
+type JSONDBaaS struct {
+	Clusters []JSONDBaaSCluster `json:"clusters"`
+	Issues   []string           `json:"issues"`
+}
+
+type JSONDBaaSCluster struct {
+	Engine   string `json:"engine"`
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Version  string `json:"version"`
+	Location string `json:"location"`
+	State    string `json:"state"`
+}
+
+type JSONKubernetes struct {
+	Nodes         JSONNodes         `json:"nodes"`
+	Pods          JSONPods          `json:"pods"`
+	Deployments   JSONDeployments   `json:"deployments"`
+	StatefulSets  JSONStatefulSets  `json:"statefulsets"`
+	DaemonSets    JSONDaemonSets    `json:"daemonsets"`
+	Jobs          JSONJobs          `json:"jobs"`
+	CronJobs      JSONCronJobs      `json:"cronjobs"`
+	HPAs          JSONHPAs          `json:"hpas"`
+	PDBs          JSONPDBs          `json:"pdbs"`
+	PVCs          JSONPVCs          `json:"pvcs"`
+	LoadBalancers JSONLoadBalancers `json:"load_balancers"`
+	Services      JSONServices      `json:"services"`
+	Ingresses     JSONIngresses     `json:"ingresses"`
+	WarningEvents []string          `json:"warning_events"`
+	Events        []JSONEvent       `json:"events"`
+	Certificates  JSONCertificates  `json:"certificates"`
+}
+
+type JSONEvent struct {
+	Reason    string    `json:"reason"`
+	Kind      string    `json:"kind"`
+	Namespace string    `json:"namespace"`
+	Name      string    `json:"name"`
+	Message   string    `json:"message"`
+	Count     int       `json:"count"`
+	LastSeen  time.Time `json:"last_seen"`
+}
+
+type JSONNodes struct {
+	Total      int      `json:"total"`
+	Ready      int      `json:"ready"`
+	NotReady   []string `json:"not_ready"`
+	Conditions []string `json:"conditions"`
+}
+
+type JSONPods struct {
+	Total            int            `json:"total"`
+	Running          int            `json:"running"`
+	CrashLoopBackOff []string       `json:"crash_loop_back_off"`
+	ImagePullBackOff []string       `json:"image_pull_back_off"`
+	Pending          []string       `json:"pending"`
+	Failed           []string       `json:"failed"`
+	NotReady         []JSONPodIssue `json:"not_ready"`
+	InitFailed       []JSONPodIssue `json:"init_failed"`
+	Restarts         []JSONPodIssue `json:"restarts"`
+	OOMKilled        []JSONPodIssue `json:"oom_killed"`
+	Terminating      []JSONPodIssue `json:"terminating"`
+	Evicted          []JSONEvicted  `json:"evicted"`
+	Unknown          []JSONPodIssue `json:"unknown"`
+}
+
+type JSONEvicted struct {
+	Node string   `json:"node"`
+	Pods []string `json:"pods"`
+}
+
+type JSONPodIssue struct {
+	Namespace string     `json:"namespace"`
+	Name      string     `json:"name"`
+	Container string     `json:"container,omitempty"`
+	Node      string     `json:"node,omitempty"`
+	Reason    string     `json:"reason"`
+	ExitCode  int32      `json:"exit_code"`
+	Restarts  int32      `json:"restarts"`
+	Since     *time.Time `json:"since,omitempty"`
+}
+
+type JSONDeployments struct {
+	Total       int      `json:"total"`
+	Available   int      `json:"available"`
+	Unavailable []string `json:"unavailable"`
+}
+
+type JSONStatefulSets struct {
+	Total        int      `json:"total"`
+	Ready        int      `json:"ready"`
+	Unavailable  []string `json:"unavailable"`
+	RolloutStuck []string `json:"rollout_stuck"`
+}
+
+type JSONDaemonSets struct {
+	Total        int      `json:"total"`
+	Ready        int      `json:"ready"`
+	Unavailable  []string `json:"unavailable"`
+	Misscheduled []string `json:"misscheduled"`
+}
+
+type JSONJobs struct {
+	Total  int             `json:"total"`
+	Failed []JSONFailedJob `json:"failed"`
+}
+
+type JSONFailedJob struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Reason    string `json:"reason"`
+	Message   string `json:"message"`
+	CronJob   string `json:"cronjob,omitempty"`
+}
+
+type JSONCronJobs struct {
+	Total      int                 `json:"total"`
+	OnSchedule int                 `json:"on_schedule"`
+	Missed     []JSONMissedCronJob `json:"missed"`
+	Suspended  []string            `json:"suspended"`
+}
+
+type JSONMissedCronJob struct {
+	Namespace   string     `json:"namespace"`
+	Name        string     `json:"name"`
+	Schedule    string     `json:"schedule"`
+	LastSuccess *time.Time `json:"last_success,omitempty"`
+	ExpectedAt  time.Time  `json:"expected_at"`
+}
+
+type JSONHPAs struct {
+	Total           int            `json:"total"`
+	Healthy         int            `json:"healthy"`
+	ScalingInactive []JSONHPAIssue `json:"scaling_inactive"`
+	AtMax           []JSONHPAIssue `json:"at_max"`
+}
+
+type JSONHPAIssue struct {
+	Namespace       string `json:"namespace"`
+	Name            string `json:"name"`
+	CurrentReplicas int32  `json:"current_replicas"`
+	MaxReplicas     int32  `json:"max_replicas"`
+	Reason          string `json:"reason"`
+	Message         string `json:"message"`
+}
+
+type JSONPDBs struct {
+	Total    int            `json:"total"`
+	Healthy  int            `json:"healthy"`
+	Blocking []JSONPDBIssue `json:"blocking"`
+}
+
+type JSONPDBIssue struct {
+	Namespace      string `json:"namespace"`
+	Name           string `json:"name"`
+	CurrentHealthy int32  `json:"current_healthy"`
+	DesiredHealthy int32  `json:"desired_healthy"`
+	ExpectedPods   int32  `json:"expected_pods"`
+}
+
+type JSONPVCs struct {
+	Total   int      `json:"total"`
+	Bound   int      `json:"bound"`
+	Pending []string `json:"pending"`
+}
+
+type JSONLoadBalancers struct {
+	Total int      `json:"total"`
+	Ready int      `json:"ready"`
+	NoIP  []string `json:"no_ip"`
+}
+
+type JSONServices struct {
+	Total       int      `json:"total"`
+	Ready       int      `json:"ready"`
+	NoEndpoints []string `json:"no_endpoints"`
+}
+
+type JSONIngresses struct {
+	Total      int                `json:"total"`
+	Ready      int                `json:"ready"`
+	NoBackends []JSONIngressIssue `json:"no_backends"`
+}
+
+type JSONIngressIssue struct {
+	Namespace string   `json:"namespace"`
+	Name      string   `json:"name"`
+	Services  []string `json:"services"`
+}
+
+type JSONCertificates struct {
+	Total    int               `json:"total"`
+	Valid    int               `json:"valid"`
+	Expiring []JSONCertificate `json:"expiring"`
+	Expired  []JSONCertificate `json:"expired"`
+}
+
+type JSONCertificate struct {
+	Host          string    `json:"host"`
+	Namespace     string    `json:"namespace"`
+	Secret        string    `json:"secret"`
+	ExpiresInDays int       `json:"expires_in_days"`
+	ExpiresAt     time.Time `json:"expires_at"`
+}
+
+type JSONIssue struct {
+	Severity  string        `json:"severity"`
+	Check     string        `json:"check"`
+	Subsystem string        `json:"subsystem"`
+	Resource  *JSONResource `json:"resource,omitempty"`
+	Reason    string        `json:"reason,omitempty"`
+	Message   string        `json:"message"`
+	Hint      string        `json:"hint,omitempty"`
+}
+
+type JSONResource struct {
+	Kind      string           `json:"kind"`
+	Name      string           `json:"name"`
+	ID        string           `json:"id,omitempty"`
+	Namespace string           `json:"namespace,omitempty"`
+	Parent    *JSONResourceRef `json:"parent,omitempty"`
+}
+
+type JSONResourceRef struct {
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+	ID   string `json:"id"`
+}
+
+func WriteJSON(w io.Writer, report *Report) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(NewJSONReport(report))
+}
+
+func NewJSONReport(report *Report) JSONReport {
+	out := JSONReport{
+		SchemaVersion:   SchemaVersion,
+		Status:          report.Status,
+		StartedAt:       report.StartedAt.UTC(),
+		FinishedAt:      report.FinishedAt.UTC(),
+		DurationSeconds: report.FinishedAt.Sub(report.StartedAt).Seconds(),
+		Issues:          []JSONIssue{},
+	}
+
+	for _, issue := range report.Issues {
+		out.Summary.Issues++
+		switch issue.Severity {
+		case SeverityCritical:
+			out.Summary.Critical++
+		case SeverityWarning:
+			out.Summary.Warning++
+		default:
+			out.Summary.Info++
+		}
+		out.Issues = append(out.Issues, NewJSONIssue(issue))
+	}
+
+	if report.StatusPage != nil {
+		out.StatusPage = &JSONStatusPage{Status: string(report.StatusPage.Status), Incidents: []JSONIncident{}}
+		for _, incident := range report.StatusPage.ActiveIncidents {
+			out.StatusPage.Incidents = append(out.StatusPage.Incidents, JSONIncident{
+				Title:   incident.Title,
+				Link:    incident.Link.Href,
+				Updated: incident.Updated,
+			})
+		}
+	}
+
+	if report.APICheck != nil || report.AuthCheck != nil || report.Datacenters != nil || report.Clusters != nil || report.DBaaS != nil {
+		out.IONOS = jsonIONOS(report)
+	}
+
+	if report.Health != nil {
+		out.Kubernetes = jsonKubernetes(report.Health)
+	}
+
+	return out
+}
+
+func NewJSONIssue(issue Issue) JSONIssue {
+	out := JSONIssue{
+		Severity:  string(issue.Severity),
+		Check:     issue.Check,
+		Subsystem: issue.Subsystem,
+		Reason:    issue.Reason,
+		Message:   issue.Message,
+		Hint:      issue.Hint,
+	}
+	if issue.Resource != (Resource{}) {
+		out.Resource = &JSONResource{
+			Kind:      issue.Resource.Kind,
+			Name:      issue.Resource.Name,
+			ID:        issue.Resource.ID,
+			Namespace: issue.Resource.Namespace,
+		}
+		if parent := issue.Resource.Parent; parent != (ResourceRef{}) {
+			out.Resource.Parent = &JSONResourceRef{Kind: parent.Kind, Name: parent.Name, ID: parent.ID}
+		}
+	}
+	return out
+}
+
+func jsonCheck(result *ionos.CheckResult) *JSONCheck {
+	if result == nil {
+		return nil
+	}
+	return &JSONCheck{OK: result.OK, Message: result.Message}
+}
+
+func jsonIONOS(report *Report) *JSONIONOS {
+	out := &JSONIONOS{
+		API:         jsonCheck(report.APICheck),
+		Auth:        jsonCheck(report.AuthCheck),
+		Datacenters: []JSONDatacenter{},
+		K8sClusters: []JSONK8sCluster{},
+	}
+
+	for _, status := range report.Datacenters {
+		dc := JSONDatacenter{
+			ID:       status.Datacenter.ID,
+			Name:     status.Datacenter.Properties.Name,
+			Location: status.Datacenter.Properties.Location,
+			Servers:  []JSONResourceState{},
+			Volumes:  []JSONResourceState{},
+			Issues:   resourceIssueMessages(status.Issues),
+		}
+		for _, srv := range status.Servers {
+			dc.Servers = append(dc.Servers, JSONResourceState{ID: srv.ID, Name: srv.Properties.Name, State: srv.Metadata.State})
+		}
+		for _, vol := range status.Volumes {
+			dc.Volumes = append(dc.Volumes, JSONResourceState{ID: vol.ID, Name: vol.Properties.Name, State: vol.Metadata.State})
+		}
+		for _, nic := range status.NICs {
+			dc.NICs = append(dc.NICs, JSONResourceState{ID: nic.ID, Name: nic.Properties.Name, State: nic.Metadata.State})
+		}
+		for _, lan := range status.LANs {
+			dc.LANs = append(dc.LANs, JSONResourceState{ID: lan.ID, Name: lan.Properties.Name, State: lan.Metadata.State})
+		}
+		out.Datacenters = append(out.Datacenters, dc)
+	}
+
+	for _, status := range report.Clusters {
+		cluster := JSONK8sCluster{
+			ID:        status.Cluster.ID,
+			Name:      status.Cluster.Properties.Name,
+			Version:   status.Cluster.Properties.K8sVersion,
+			State:     status.Cluster.Metadata.State,
+			NodePools: []JSONNodePool{},
+			Issues:    resourceIssueMessages(status.Issues),
+		}
+		for _, np := range status.NodePools {
+			cluster.NodePools = append(cluster.NodePools, JSONNodePool{
+				ID:        np.ID,
+				Name:      np.Properties.Name,
+				Version:   np.Properties.K8sVersion,
+				State:     np.Metadata.State,
+				NodeCount: np.Properties.NodeCount,
+			})
+		}
+		out.K8sClusters = append(out.K8sClusters, cluster)
+	}
+
+	if report.DBaaS != nil {
+		out.DBaaS = jsonDBaaS(report.DBaaS)
+	}
+
+	return out
+}
+
+func resourceIssueMessages(issues []ionos.ResourceIssue) []string {
+	out := []string{}
+	for _, issue := range issues {
+		out = append(out, issue.Message)
+	}
+	return out
+}
+
+func jsonDBaaS(status *ionos.DBaaSStatus) *JSONDBaaS {
+	out := &JSONDBaaS{Clusters: []JSONDBaaSCluster{}, Issues: []string{}}
+	for _, issue := range status.Issues {
+		out.Issues = append(out.Issues, issue.Message)
+	}
+	for _, c := range status.PostgreSQL {
+		out.Clusters = append(out.Clusters, JSONDBaaSCluster{
+			Engine: "postgresql", ID: c.ID, Name: c.Properties.DisplayName,
+			Version: c.Properties.PostgresVersion, Location: c.Properties.Location, State: c.Metadata.State,
+		})
+	}
+	for _, c := range status.MongoDB {
+		out.Clusters = append(out.Clusters, JSONDBaaSCluster{
+			Engine: "mongodb", ID: c.ID, Name: c.Properties.DisplayName,
+			Version: c.Properties.MongoDBVersion, Location: c.Properties.Location, State: c.Metadata.State,
+		})
+	}
+	for _, c := range status.MariaDB {
+		out.Clusters = append(out.Clusters, JSONDBaaSCluster{
+			Engine: "mariadb", ID: c.ID, Name: c.Properties.DisplayName,
+			Version: c.Properties.MariaDBVersion, Location: c.Properties.Location, State: c.Metadata.State,
+		})
+	}
+	for _, i := range status.InMemoryDB {
+		out.Clusters = append(out.Clusters, JSONDBaaSCluster{
+			Engine: "inmemorydb", ID: i.ID, Name: i.Properties.DisplayName,
+			Version: i.Properties.Version, Location: i.Properties.Location, State: i.Metadata.State,
+		})
+	}
+	return out
+}
+
+func jsonKubernetes(health *k8s.HealthResult) *JSONKubernetes {
+	return &JSONKubernetes{
+		Nodes: JSONNodes{
+			Total:      health.Nodes.Total,
+			Ready:      health.Nodes.Ready,
+			NotReady:   nonNil(health.Nodes.NotReady),
+			Conditions: nonNil(health.Nodes.Conditions),
+		},
+		Pods: JSONPods{
+			Total:            health.Pods.Total,
+			Running:          health.Pods.Running,
+			CrashLoopBackOff: nonNil(health.Pods.CrashLoopBackOff),
+			ImagePullBackOff: nonNil(health.Pods.ImagePullBackOff),
+			Pending:          nonNil(health.Pods.Pending),
+			Failed:           nonNil(health.Pods.Failed),
+			NotReady:         jsonPodIssues(health.Pods.NotReady),
+			InitFailed:       jsonPodIssues(health.Pods.InitFailed),
+			Restarts:         jsonPodIssues(health.Pods.Restarts),
+			OOMKilled:        jsonPodIssues(health.Pods.OOMKilled),
+			Terminating:      jsonPodIssues(health.Pods.Terminating),
+			Evicted:          jsonEvicted(health.Pods.Evicted),
+			Unknown:          jsonPodIssues(health.Pods.Unknown),
+		},
+		Deployments: JSONDeployments{
+			Total:       health.Deployments.Total,
+			Available:   health.Deployments.Available,
+			Unavailable: nonNil(health.Deployments.Unavailable),
+		},
+		StatefulSets: JSONStatefulSets{
+			Total:        health.StatefulSets.Total,
+			Ready:        health.StatefulSets.Ready,
+			Unavailable:  nonNil(health.StatefulSets.Unavailable),
+			RolloutStuck: nonNil(health.StatefulSets.RolloutStuck),
+		},
+		DaemonSets: JSONDaemonSets{
+			Total:        health.DaemonSets.Total,
+			Ready:        health.DaemonSets.Ready,
+			Unavailable:  nonNil(health.DaemonSets.Unavailable),
+			Misscheduled: nonNil(health.DaemonSets.Misscheduled),
+		},
+		Jobs: JSONJobs{
+			Total:  health.Jobs.Total,
+			Failed: jsonFailedJobs(health.Jobs.Failed),
+		},
+		CronJobs: JSONCronJobs{
+			Total:      health.CronJobs.Total,
+			OnSchedule: health.CronJobs.OnSchedule,
+			Missed:     jsonMissedCronJobs(health.CronJobs.Missed),
+			Suspended:  nonNil(health.CronJobs.Suspended),
+		},
+		HPAs: JSONHPAs{
+			Total:           health.HPAs.Total,
+			Healthy:         health.HPAs.Healthy,
+			ScalingInactive: jsonHPAIssues(health.HPAs.ScalingInactive),
+			AtMax:           jsonHPAIssues(health.HPAs.AtMax),
+		},
+		PDBs: JSONPDBs{
+			Total:    health.PDBs.Total,
+			Healthy:  health.PDBs.Healthy,
+			Blocking: jsonPDBIssues(health.PDBs.Blocking),
+		},
+		PVCs: JSONPVCs{
+			Total:   health.PVCs.Total,
+			Bound:   health.PVCs.Bound,
+			Pending: nonNil(health.PVCs.Pending),
+		},
+		LoadBalancers: JSONLoadBalancers{
+			Total: health.Services.Total,
+			Ready: health.Services.Ready,
+			NoIP:  nonNil(health.Services.NoIP),
+		},
+		Services: JSONServices{
+			Total:       health.Endpoints.Total,
+			Ready:       health.Endpoints.Ready,
+			NoEndpoints: nonNil(health.Endpoints.NoEndpoints),
+		},
+		Ingresses: JSONIngresses{
+			Total:      health.Endpoints.Ingresses,
+			Ready:      health.Endpoints.IngressesReady,
+			NoBackends: jsonIngressIssues(health.Endpoints.IngressNoBackends),
+		},
+		WarningEvents: nonNil(health.Events.Warnings),
+		Events:        jsonEvents(health.Events.Groups),
+		Certificates: JSONCertificates{
+			Total:    health.Certs.Total,
+			Valid:    health.Certs.Valid,
+			Expiring: jsonCertificates(health.Certs.Expiring),
+			Expired:  jsonCertificates(health.Certs.Expired),
+		},
+	}
+}
+
+func jsonCertificates(certs []k8s.CertInfo) []JSONCertificate {
+	out := []JSONCertificate{}
+	for _, cert := range certs {
+		out = append(out, JSONCertificate{
+			Host:          cert.Host,
+			Namespace:     cert.Namespace,
+			Secret:        cert.Secret,
+			ExpiresInDays: cert.ExpiresIn,
+			ExpiresAt:     cert.Expiry.UTC(),
+		})
+	}
+	return out
+}
+
+func jsonPodIssues(pods []k8s.PodIssue) []JSONPodIssue {
+	out := []JSONPodIssue{}
+	for _, pod := range pods {
+		issue := JSONPodIssue{
+			Namespace: pod.Namespace,
+			Name:      pod.Name,
+			Container: pod.Container,
+			Node:      pod.Node,
+			Reason:    pod.Reason,
+			ExitCode:  pod.ExitCode,
+			Restarts:  pod.Restarts,
+		}
+		if !pod.Since.IsZero() {
+			since := pod.Since.UTC()
+			issue.Since = &since
+		}
+		out = append(out, issue)
+	}
+	return out
+}
+
+func jsonEvicted(groups []k8s.EvictedPods) []JSONEvicted {
+	out := []JSONEvicted{}
+	for _, group := range groups {
+		out = append(out, JSONEvicted{Node: group.Node, Pods: nonNil(group.Pods)})
+	}
+	return out
+}
+
+func jsonHPAIssues(hpas []k8s.HPAIssue) []JSONHPAIssue {
+	out := []JSONHPAIssue{}
+	for _, hpa := range hpas {
+		out = append(out, JSONHPAIssue(hpa))
+	}
+	return out
+}
+
+func jsonPDBIssues(pdbs []k8s.PDBIssue) []JSONPDBIssue {
+	out := []JSONPDBIssue{}
+	for _, pdb := range pdbs {
+		out = append(out, JSONPDBIssue(pdb))
+	}
+	return out
+}
+
+func jsonIngressIssues(ingresses []k8s.IngressIssue) []JSONIngressIssue {
+	out := []JSONIngressIssue{}
+	for _, ing := range ingresses {
+		out = append(out, JSONIngressIssue{Namespace: ing.Namespace, Name: ing.Name, Services: nonNil(ing.Services)})
+	}
+	return out
+}
+
+func jsonFailedJobs(jobs []k8s.FailedJob) []JSONFailedJob {
+	out := []JSONFailedJob{}
+	for _, job := range jobs {
+		out = append(out, JSONFailedJob{
+			Namespace: job.Namespace,
+			Name:      job.Name,
+			Reason:    job.Reason,
+			Message:   job.Message,
+			CronJob:   job.CronJob,
+		})
+	}
+	return out
+}
+
+func jsonMissedCronJobs(cronJobs []k8s.MissedCronJob) []JSONMissedCronJob {
+	out := []JSONMissedCronJob{}
+	for _, cj := range cronJobs {
+		missed := JSONMissedCronJob{
+			Namespace:  cj.Namespace,
+			Name:       cj.Name,
+			Schedule:   cj.Schedule,
+			ExpectedAt: cj.ExpectedAt.UTC(),
+		}
+		if !cj.LastSuccess.IsZero() {
+			lastSuccess := cj.LastSuccess.UTC()
+			missed.LastSuccess = &lastSuccess
+		}
+		out = append(out, missed)
+	}
+	return out
+}
+
+func jsonEvents(groups []k8s.EventGroup) []JSONEvent {
+	out := []JSONEvent{}
+	for _, group := range groups {
+		out = append(out, JSONEvent{
+			Reason:    group.Reason,
+			Kind:      group.Kind,
+			Namespace: group.Namespace,
+			Name:      group.Name,
+			Message:   group.Message,
+			Count:     group.Count,
+			LastSeen:  group.LastSeen.UTC(),
+		})
+	}
+	return out
+}
+
+// nonNil keeps empty lists as [] rather than null in the JSON output.
+func nonNil(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
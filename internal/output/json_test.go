@@ -0,0 +1,150 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/peterpisarcik/ionos-cloud-watchdog/internal/feed"
+	"github.com/peterpisarcik/ionos-cloud-watchdog/internal/ionos"
+	"github.com/peterpisarcik/ionos-cloud-watchdog/internal/k8s"
+)
+
+func TestWriteJSON_StableModel(t *testing.T) {
+	started := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	report := &Report{
+		Status:     "WARNING",
+		StartedAt:  started,
+		FinishedAt: started.Add(1500 * time.Millisecond),
+		StatusPage: &feed.StatusResult{Status: feed.StatusOK},
+		APICheck:   &ionos.CheckResult{OK: true, Message: "IONOS API is reachable"},
+		DBaaS:      &ionos.DBaaSStatus{},
+		Health:     &k8s.HealthResult{Pods: k8s.PodResult{Total: 1, Pending: []string{"ns/a"}}},
+		Issues: []Issue{{
+			Severity:  SeverityWarning,
+			Check:     CheckPods,
+			Subsystem: SubsystemKubernetes,
+			Resource:  Resource{Kind: "Pod", Namespace: "ns", Name: "a"},
+			Message:   "Pod ns/a Pending",
+		}},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteJSON(&buf, report); err != nil {
+		t.Fatalf("WriteJSON returned error: %v", err)
+	}
+	out := buf.String()
+
+	for _, expected := range []string{
+		`"schema_version": "1"`,
+		`"started_at": "2025-01-02T03:04:05Z"`,
+		`"duration_seconds": 1.5`,
+		`"pending": [`,
+		`"crash_loop_back_off": []`,
+		`"datacenters": []`,
+		`"namespace": "ns"`,
+	} {
+		if !strings.Contains(out, expected) {
+			t.Fatalf("expected %s in output:\n%s", expected, out)
+		}
+	}
+
+	var decoded JSONReport
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("invalid json: %v", err)
+	}
+	if decoded.Summary.Issues != 1 || decoded.Summary.Warning != 1 {
+		t.Fatalf("unexpected summary: %+v", decoded.Summary)
+	}
+	if decoded.IONOS == nil || decoded.IONOS.API == nil || !decoded.IONOS.API.OK {
+		t.Fatalf("expected ionos api check in output: %+v", decoded.IONOS)
+	}
+}
+
+func TestSchemaMatchesJSONModel(t *testing.T) {
+	var root map[string]any
+	if err := json.Unmarshal(Schema(), &root); err != nil {
+		t.Fatalf("schema is not valid json: %v", err)
+	}
+
+	version := root["properties"].(map[string]any)["schema_version"].(map[string]any)["const"]
+	if version != SchemaVersion {
+		t.Fatalf("schema const %v does not match SchemaVersion %s", version, SchemaVersion)
+	}
+
+	assertSchemaMatches(t, root, root, reflect.TypeOf(JSONReport{}), "$")
+}
+
+// assertSchemaMatches checks that every field of the Go model is described by
+// the schema, is required unless omitempty, and that the schema has no extra
+// properties.
+func assertSchemaMatches(t *testing.T, root, node map[string]any, typ reflect.Type, path string) {
+	t.Helper()
+	node = resolveRef(t, root, node)
+
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+
+	switch {
+	case typ.Kind() == reflect.Slice:
+		items, ok := node["items"].(map[string]any)
+		if !ok {
+			t.Fatalf("%s: expected array items in schema", path)
+		}
+		assertSchemaMatches(t, root, items, typ.Elem(), path+"[]")
+		return
+	case typ.Kind() != reflect.Struct || typ == reflect.TypeOf(time.Time{}):
+		return
+	}
+
+	properties, _ := node["properties"].(map[string]any)
+	required := map[string]bool{}
+	if list, ok := node["required"].([]any); ok {
+		for _, name := range list {
+			required[name.(string)] = true
+		}
+	}
+
+	fields := map[string]bool{}
+	for i := range typ.NumField() {
+		field := typ.Field(i)
+		name, opts, _ := strings.Cut(field.Tag.Get("json"), ",")
+		fields[name] = true
+
+		property, ok := properties[name].(map[string]any)
+		if !ok {
+			t.Fatalf("%s.%s: missing from schema", path, name)
+		}
+		if required[name] == (opts == "omitempty") {
+			t.Fatalf("%s.%s: required in schema must match omitempty in model", path, name)
+		}
+		assertSchemaMatches(t, root, property, field.Type, path+"."+name)
+	}
+
+	for name := range properties {
+		if !fields[name] {
+			t.Fatalf("%s.%s: described in schema but not in model", path, name)
+		}
+	}
+}
+
+func resolveRef(t *testing.T, root, node map[string]any) map[string]any {
+	t.Helper()
+	ref, ok := node["$ref"].(string)
+	if !ok {
+		return node
+	}
+	name, ok := strings.CutPrefix(ref, "#/$defs/")
+	if !ok {
+		t.Fatalf("unsupported $ref %s", ref)
+	}
+	def, ok := root["$defs"].(map[string]any)[name].(map[string]any)
+	if !ok {
+		t.Fatalf("unknown $ref %s", ref)
+	}
+	return def
+}
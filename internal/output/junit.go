@@ -0,0 +1,111 @@
+package output
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Errors    int             `xml:"errors,attr"`
+	Timestamp string          `xml:"timestamp,attr,omitempty"`
+	Cases     []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string         `xml:"name,attr"`
+	ClassName string         `xml:"classname,attr"`
+	Failures  []junitFailure `xml:"failure"`
+	SystemOut string         `xml:"system-out,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// WriteJUnit prints the report as a JUnit XML test report with one test suite
+// per subsystem and one test case per service. Warning and critical issues
+// are failures; info issues are only listed in the test case output.
+func WriteJUnit(w io.Writer, report *Report) error {
+	out := junitTestSuites{
+		Name: "ionos-cloud-watchdog",
+		Time: junitDuration(report.FinishedAt.Sub(report.StartedAt)),
+	}
+
+	suites := make(map[string]*junitTestSuite)
+	for _, group := range []string{SubsystemStatusPage, SubsystemIONOS, SubsystemKubernetes} {
+		suites[group] = &junitTestSuite{Name: group}
+		if !report.StartedAt.IsZero() {
+			suites[group].Timestamp = report.StartedAt.UTC().Format(time.RFC3339)
+		}
+	}
+
+	for _, s := range buildServices(report, true) {
+		suite := suites[s.Group]
+		testCase := junitTestCase{Name: s.Name, ClassName: s.ID}
+
+		output := []string{s.Summary}
+		for _, issue := range s.Issues {
+			if issue.Severity.rank() == 0 {
+				output = append(output, fmt.Sprintf("[%s] %s", strings.ToUpper(string(issue.Severity)), issue.Message))
+				continue
+			}
+			text := issue.Message
+			if issue.Hint != "" {
+				text += "\nHint: " + issue.Hint
+			}
+			testCase.Failures = append(testCase.Failures, junitFailure{Message: issue.Message, Type: string(issue.Severity), Text: text})
+		}
+		testCase.SystemOut = strings.TrimSpace(strings.Join(output, "\n"))
+
+		suite.Tests++
+		if len(testCase.Failures) > 0 {
+			suite.Failures++
+		}
+		suite.Cases = append(suite.Cases, testCase)
+	}
+
+	for _, group := range []string{SubsystemStatusPage, SubsystemIONOS, SubsystemKubernetes} {
+		suite := suites[group]
+		if suite.Tests == 0 {
+			continue
+		}
+		out.Tests += suite.Tests
+		out.Failures += suite.Failures
+		out.Suites = append(out.Suites, *suite)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(out); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func junitDuration(d time.Duration) string {
+	if d < 0 {
+		d = 0
+	}
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
@@ -0,0 +1,76 @@
+package output
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWriteJUnit(t *testing.T) {
+	report := serviceReport()
+	report.StartedAt = time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	report.FinishedAt = report.StartedAt.Add(1500 * time.Millisecond)
+	report.Issues = append(report.Issues,
+		Issue{Severity: SeverityInfo, Check: CheckPods, Subsystem: SubsystemKubernetes, Message: "Pod batch/job Pending"},
+		Issue{Severity: SeverityWarning, Check: CheckDBaaS, Subsystem: SubsystemIONOS, Resource: Resource{Kind: "DBaaS", Name: "mongodb"}, Message: "DBaaS: Failed to get MongoDB clusters: boom"},
+	)
+
+	var buf bytes.Buffer
+	if err := WriteJUnit(&buf, report); err != nil {
+		t.Fatalf("WriteJUnit returned error: %v", err)
+	}
+	if !strings.HasPrefix(buf.String(), xml.Header) {
+		t.Fatalf("expected XML header, got %s", buf.String())
+	}
+
+	var out junitTestSuites
+	if err := xml.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("invalid XML: %v", err)
+	}
+	if out.Time != "1.500" || len(out.Suites) != 3 {
+		t.Fatalf("unexpected test suites: time=%s suites=%d", out.Time, len(out.Suites))
+	}
+
+	cases := make(map[string]junitTestCase)
+	for _, suite := range out.Suites {
+		if suite.Timestamp != "2025-01-02T03:04:05Z" {
+			t.Fatalf("unexpected timestamp %q", suite.Timestamp)
+		}
+		for _, c := range suite.Cases {
+			cases[c.Name] = c
+		}
+	}
+
+	for name, failures := range map[string]int{
+		"IONOS Status Page":      0,
+		"IONOS API":              1,
+		"IONOS Authentication":   0,
+		"IONOS Datacenter prod":  1,
+		"IONOS DBaaS postgresql": 1,
+		"IONOS DBaaS mongodb":    1,
+		"IONOS DBaaS mariadb":    0,
+		"Kubernetes Nodes":       1,
+		"Kubernetes Pods":        0,
+	} {
+		c, ok := cases[name]
+		if !ok {
+			t.Fatalf("missing test case %q", name)
+		}
+		if len(c.Failures) != failures {
+			t.Fatalf("expected %d failures for %q, got %+v", failures, name, c.Failures)
+		}
+	}
+
+	nodes := cases["Kubernetes Nodes"].Failures[0]
+	if nodes.Type != "critical" || nodes.Message != "Node node-3 NotReady" {
+		t.Fatalf("unexpected failure: %+v", nodes)
+	}
+	if !strings.Contains(cases["Kubernetes Pods"].SystemOut, "[INFO] Pod batch/job Pending") {
+		t.Fatalf("expected info issue in system-out, got %q", cases["Kubernetes Pods"].SystemOut)
+	}
+	if out.Failures != 5 {
+		t.Fatalf("expected 5 failing test cases, got %d", out.Failures)
+	}
+}
@@ -0,0 +1,253 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// WriteMarkdown prints the sections of PrintText as GitHub-flavoured Markdown
+// tables. Verbose output adds server, volume and node pool tables and hints.
+func WriteMarkdown(w io.Writer, report *Report, cfg *Config) error {
+	r := NewJSONReport(report)
+	b := &strings.Builder{}
+
+	fmt.Fprintf(b, "## IONOS Cloud Watchdog: %s\n\n", r.Status)
+	fmt.Fprintf(b, "%d issues: %d critical, %d warning, %d info.\n", r.Summary.Issues, r.Summary.Critical, r.Summary.Warning, r.Summary.Info)
+
+	markdownIONOSCloud(b, r)
+	if r.IONOS != nil {
+		markdownDatacenters(b, r.IONOS.Datacenters, cfg.Verbose)
+		markdownClusters(b, r.IONOS.K8sClusters, cfg.Verbose)
+		markdownDBaaS(b, r.IONOS.DBaaS)
+	}
+	markdownHealth(b, r.Kubernetes, cfg.Verbose)
+	markdownIssues(b, r.Issues, cfg.Verbose)
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func markdownTable(b *strings.Builder, header []string, rows [][]string) {
+	b.WriteString("\n")
+	writeRow := func(cells []string) {
+		b.WriteString("|")
+		for _, cell := range cells {
+			b.WriteString(" " + markdownCell(cell) + " |")
+		}
+		b.WriteString("\n")
+	}
+	writeRow(header)
+	b.WriteString("|" + strings.Repeat(" --- |", len(header)) + "\n")
+	for _, row := range rows {
+		writeRow(row)
+	}
+}
+
+func markdownCell(s string) string {
+	return strings.NewReplacer("|", `\|`, "\r", "", "\n", "<br>").Replace(s)
+}
+
+func markdownSection(b *strings.Builder, title string) {
+	fmt.Fprintf(b, "\n### %s\n", title)
+}
+
+func okText(ok bool) string {
+	if ok {
+		return "OK"
+	}
+	return "FAILED"
+}
+
+func markdownIONOSCloud(b *strings.Builder, r JSONReport) {
+	var rows [][]string
+	if r.StatusPage != nil {
+		rows = append(rows, []string{"Status Page", r.StatusPage.Status, ""})
+	}
+	if r.IONOS != nil && r.IONOS.API != nil {
+		rows = append(rows, []string{"API", okText(r.IONOS.API.OK), r.IONOS.API.Message})
+	} else {
+		rows = append(rows, []string{"API", "SKIPPED", ""})
+	}
+	if r.IONOS != nil && r.IONOS.Auth != nil {
+		rows = append(rows, []string{"Authentication", okText(r.IONOS.Auth.OK), r.IONOS.Auth.Message})
+	}
+
+	markdownSection(b, "IONOS Cloud")
+	markdownTable(b, []string{"Check", "Status", "Details"}, rows)
+
+	if r.StatusPage != nil && len(r.StatusPage.Incidents) > 0 {
+		b.WriteString("\nActive incidents:\n\n")
+		for _, incident := range r.StatusPage.Incidents {
+			if incident.Link != "" {
+				fmt.Fprintf(b, "- [%s](%s)\n", incident.Title, incident.Link)
+			} else {
+				fmt.Fprintf(b, "- %s\n", incident.Title)
+			}
+		}
+	}
+}
+
+func stateText(issues []string) string {
+	if len(issues) == 0 {
+		return "OK"
+	}
+	return "ISSUES"
+}
+
+func markdownDatacenters(b *strings.Builder, datacenters []JSONDatacenter, verbose bool) {
+	if len(datacenters) == 0 {
+		return
+	}
+
+	rows := make([][]string, 0, len(datacenters))
+	for _, dc := range datacenters {
+		rows = append(rows, []string{dc.Name, dc.Location, fmt.Sprint(len(dc.Servers)), fmt.Sprint(len(dc.Volumes)), stateText(dc.Issues)})
+	}
+	markdownSection(b, "Datacenters")
+	markdownTable(b, []string{"Datacenter", "Location", "Servers", "Volumes", "State"}, rows)
+
+	if !verbose {
+		return
+	}
+	for _, dc := range datacenters {
+		var resources [][]string
+		for _, srv := range dc.Servers {
+			resources = append(resources, []string{"Server", srv.Name, srv.State})
+		}
+		for _, vol := range dc.Volumes {
+			resources = append(resources, []string{"Volume", vol.Name, vol.State})
+		}
+		if len(resources) == 0 {
+			continue
+		}
+		fmt.Fprintf(b, "\n<details><summary>%s</summary>\n", markdownCell(dc.Name))
+		markdownTable(b, []string{"Type", "Name", "State"}, resources)
+		b.WriteString("\n</details>\n")
+	}
+}
+
+func markdownClusters(b *strings.Builder, clusters []JSONK8sCluster, verbose bool) {
+	if len(clusters) == 0 {
+		return
+	}
+
+	rows := make([][]string, 0, len(clusters))
+	for _, cluster := range clusters {
+		rows = append(rows, []string{cluster.Name, cluster.Version, cluster.State, fmt.Sprint(len(cluster.NodePools)), stateText(cluster.Issues)})
+	}
+	markdownSection(b, "Kubernetes Clusters")
+	markdownTable(b, []string{"Cluster", "Version", "State", "Node Pools", "Issues"}, rows)
+
+	if !verbose {
+		return
+	}
+	var pools [][]string
+	for _, cluster := range clusters {
+		for _, np := range cluster.NodePools {
+			pools = append(pools, []string{cluster.Name, np.Name, np.Version, fmt.Sprint(np.NodeCount), np.State})
+		}
+	}
+	if len(pools) > 0 {
+		markdownTable(b, []string{"Cluster", "Node Pool", "Version", "Nodes", "State"}, pools)
+	}
+}
+
+func markdownDBaaS(b *strings.Builder, dbaas *JSONDBaaS) {
+	if dbaas == nil || len(dbaas.Clusters) == 0 {
+		return
+	}
+
+	rows := make([][]string, 0, len(dbaas.Clusters))
+	for _, cluster := range dbaas.Clusters {
+		rows = append(rows, []string{cluster.Engine, cluster.Name, cluster.Version, cluster.Location, cluster.State})
+	}
+	markdownSection(b, "Managed Databases")
+	markdownTable(b, []string{"Engine", "Name", "Version", "Location", "State"}, rows)
+}
+
+func markdownHealth(b *strings.Builder, k *JSONKubernetes, verbose bool) {
+	if k == nil {
+		return
+	}
+
+	rows := [][]string{
+		{"Nodes", fmt.Sprintf("%d/%d Ready", k.Nodes.Ready, k.Nodes.Total)},
+		{"Pods", fmt.Sprintf("%d/%d Running", k.Pods.Running, k.Pods.Total)},
+		{"Deployments", fmt.Sprintf("%d/%d Available", k.Deployments.Available, k.Deployments.Total)},
+	}
+	if k.StatefulSets.Total > 0 {
+		rows = append(rows, []string{"StatefulSets", fmt.Sprintf("%d/%d Ready", k.StatefulSets.Ready, k.StatefulSets.Total)})
+	}
+	if k.DaemonSets.Total > 0 {
+		rows = append(rows, []string{"DaemonSets", fmt.Sprintf("%d/%d Ready", k.DaemonSets.Ready, k.DaemonSets.Total)})
+	}
+	if k.Jobs.Total > 0 {
+		rows = append(rows, []string{"Jobs", fmt.Sprintf("%d/%d OK", k.Jobs.Total-len(k.Jobs.Failed), k.Jobs.Total)})
+	}
+	if k.CronJobs.Total > 0 {
+		rows = append(rows, []string{"CronJobs", fmt.Sprintf("%d/%d On Schedule, %d suspended", k.CronJobs.OnSchedule, k.CronJobs.Total, len(k.CronJobs.Suspended))})
+	}
+	if k.HPAs.Total > 0 {
+		rows = append(rows, []string{"HPAs", fmt.Sprintf("%d/%d OK", k.HPAs.Healthy, k.HPAs.Total)})
+	}
+	if k.PDBs.Total > 0 {
+		rows = append(rows, []string{"PDBs", fmt.Sprintf("%d/%d Allow Disruptions", k.PDBs.Healthy, k.PDBs.Total)})
+	}
+	if k.PVCs.Total > 0 {
+		rows = append(rows, []string{"PVCs", fmt.Sprintf("%d/%d Bound", k.PVCs.Bound, k.PVCs.Total)})
+	}
+	if k.LoadBalancers.Total > 0 {
+		rows = append(rows, []string{"LoadBalancers", fmt.Sprintf("%d/%d Ready", k.LoadBalancers.Ready, k.LoadBalancers.Total)})
+	}
+	if k.Services.Total > 0 {
+		rows = append(rows, []string{"Services", fmt.Sprintf("%d/%d With Endpoints", k.Services.Ready, k.Services.Total)})
+	}
+	if k.Ingresses.Total > 0 {
+		rows = append(rows, []string{"Ingresses", fmt.Sprintf("%d/%d Routable", k.Ingresses.Ready, k.Ingresses.Total)})
+	}
+	if k.Certificates.Total > 0 {
+		rows = append(rows, []string{"Certificates", fmt.Sprintf("%d/%d Valid", k.Certificates.Valid, k.Certificates.Total)})
+	}
+
+	if len(k.Events) > 0 {
+		rows = append(rows, []string{"Warning Events", fmt.Sprint(len(k.Events))})
+	}
+
+	markdownSection(b, "Health")
+	markdownTable(b, []string{"Resource", "Status"}, rows)
+
+	if !verbose || len(k.Events) == 0 {
+		return
+	}
+	events := make([][]string, 0, len(k.Events))
+	for _, event := range k.Events {
+		object := event.Name
+		if event.Namespace != "" {
+			object = event.Namespace + "/" + event.Name
+		}
+		events = append(events, []string{event.Reason, event.Kind + " " + object, fmt.Sprint(event.Count), event.LastSeen.Format("2006-01-02 15:04:05 MST"), event.Message})
+	}
+	markdownTable(b, []string{"Reason", "Object", "Count", "Last Seen", "Message"}, events)
+}
+
+func markdownIssues(b *strings.Builder, issues []JSONIssue, verbose bool) {
+	if len(issues) == 0 {
+		return
+	}
+
+	header := []string{"Severity", "Check", "Message"}
+	if verbose {
+		header = append(header, "Hint")
+	}
+	rows := make([][]string, 0, len(issues))
+	for _, issue := range issues {
+		row := []string{strings.ToUpper(issue.Severity), issue.Check, issue.Message}
+		if verbose {
+			row = append(row, issue.Hint)
+		}
+		rows = append(rows, row)
+	}
+	markdownSection(b, "Issues")
+	markdownTable(b, header, rows)
+}
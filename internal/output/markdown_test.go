@@ -0,0 +1,52 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestWriteMarkdown(t *testing.T) {
+	report := serviceReport()
+	report.Issues[0].Hint = "Check the | server"
+
+	var buf bytes.Buffer
+	if err := WriteMarkdown(&buf, report, &Config{}); err != nil {
+		t.Fatalf("WriteMarkdown returned error: %v", err)
+	}
+	out := buf.String()
+
+	for _, want := range []string{
+		"## IONOS Cloud Watchdog: CRITICAL\n",
+		"| Check | Status | Details |\n| --- | --- | --- |\n| Status Page | OK |  |\n| API | OK | IONOS API is reachable |\n",
+		"| prod | de/fra | 2 | 0 | ISSUES |\n",
+		"| postgresql | orders | 15 | de/fra | BUSY |\n",
+		"| Nodes | 2/3 Ready |\n",
+		"| CRITICAL | k8s.nodes | Node node-3 NotReady |\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in output:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "Hint") || strings.Contains(out, "<details>") {
+		t.Fatalf("expected hints and resource details only in verbose mode:\n%s", out)
+	}
+}
+
+func TestWriteMarkdown_Verbose(t *testing.T) {
+	report := serviceReport()
+	report.Issues[0].Hint = "Check the | server"
+
+	var buf bytes.Buffer
+	if err := WriteMarkdown(&buf, report, &Config{Verbose: true}); err != nil {
+		t.Fatalf("WriteMarkdown returned error: %v", err)
+	}
+	out := buf.String()
+
+	if !strings.Contains(out, "| Severity | Check | Message | Hint |") || !strings.Contains(out, `Check the \| server`) {
+		t.Fatalf("expected escaped hint column:\n%s", out)
+	}
+	if !strings.Contains(out, "<details><summary>prod</summary>") {
+		t.Fatalf("expected server details:\n%s", out)
+	}
+}
@@ -0,0 +1,265 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+const metricPrefix = "ionos_watchdog_"
+
+type metricsWriter struct {
+	w       io.Writer
+	written map[string]bool
+}
+
+func (m *metricsWriter) family(name, metricType, help string) {
+	if m.written[name] {
+		return
+	}
+	m.written[name] = true
+	_, _ = fmt.Fprintf(m.w, "# HELP %s%s %s\n", metricPrefix, name, help)
+	_, _ = fmt.Fprintf(m.w, "# TYPE %s%s %s\n", metricPrefix, name, metricType)
+}
+
+func (m *metricsWriter) sample(name string, value float64, labels ...string) {
+	_, _ = fmt.Fprintf(m.w, "%s%s%s %g\n", metricPrefix, name, formatLabels(labels), value)
+}
+
+func (m *metricsWriter) gauge(name, help string, value float64, labels ...string) {
+	m.family(name, "gauge", help)
+	m.sample(name, value, labels...)
+}
+
+func formatLabels(labels []string) string {
+	if len(labels) == 0 {
+		return ""
+	}
+
+	pairs := make([]string, 0, len(labels)/2)
+	for i := 0; i+1 < len(labels); i += 2 {
+		pairs = append(pairs, fmt.Sprintf("%s=\"%s\"", labels[i], escapeLabelValue(labels[i+1])))
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+func escapeLabelValue(value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, "\n", `\n`)
+	return strings.ReplaceAll(value, `"`, `\"`)
+}
+
+func boolValue(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func WriteMetrics(w io.Writer, report *Report) {
+	m := &metricsWriter{w: w, written: make(map[string]bool)}
+
+	for _, status := range []string{"OK", "WARNING", "CRITICAL"} {
+		m.gauge("status", "Overall watchdog status (1 for the current status).", boolValue(report.Status == status), "status", status)
+	}
+	severities := make(map[Severity]int)
+	for _, issue := range report.Issues {
+		severities[issue.Severity]++
+	}
+	for _, severity := range []Severity{SeverityInfo, SeverityWarning, SeverityCritical} {
+		m.gauge("issues", "Number of issues found by the last check run per severity.", float64(severities[severity]), "severity", string(severity))
+	}
+
+	writeIONOSMetrics(m, report)
+	writeDatacenterMetrics(m, report)
+	writeClusterMetrics(m, report)
+	writeDBaaSMetrics(m, report)
+	writeHealthMetrics(m, report)
+}
+
+func writeIONOSMetrics(m *metricsWriter, report *Report) {
+	if report.StatusPage != nil {
+		m.gauge("statuspage_active_incidents", "Number of active incidents on the IONOS Cloud status page.", float64(len(report.StatusPage.ActiveIncidents)))
+	}
+	if report.APICheck != nil {
+		m.gauge("api_up", "Whether the IONOS Cloud API is reachable.", boolValue(report.APICheck.OK))
+	}
+	if report.AuthCheck != nil {
+		m.gauge("auth_ok", "Whether authentication against the IONOS Cloud API succeeded.", boolValue(report.AuthCheck.OK))
+	}
+}
+
+func writeDatacenterMetrics(m *metricsWriter, report *Report) {
+	for _, status := range report.Datacenters {
+		dc := status.Datacenter.Properties.Name
+		m.gauge("datacenter_issues", "Number of issues per datacenter.", float64(len(status.Issues)),
+			"datacenter", dc, "id", status.Datacenter.ID, "location", status.Datacenter.Properties.Location)
+	}
+
+	for _, status := range report.Datacenters {
+		serverStates := make(map[string]int)
+		for _, srv := range status.Servers {
+			serverStates[srv.Metadata.State]++
+		}
+		for _, state := range sortedKeys(serverStates) {
+			m.gauge("datacenter_servers", "Number of servers per datacenter and state.", float64(serverStates[state]),
+				"datacenter", status.Datacenter.Properties.Name, "id", status.Datacenter.ID, "state", state)
+		}
+	}
+
+	for _, status := range report.Datacenters {
+		volumeStates := make(map[string]int)
+		for _, vol := range status.Volumes {
+			volumeStates[vol.Metadata.State]++
+		}
+		for _, state := range sortedKeys(volumeStates) {
+			m.gauge("datacenter_volumes", "Number of volumes per datacenter and state.", float64(volumeStates[state]),
+				"datacenter", status.Datacenter.Properties.Name, "id", status.Datacenter.ID, "state", state)
+		}
+	}
+}
+
+func writeClusterMetrics(m *metricsWriter, report *Report) {
+	for _, status := range report.Clusters {
+		m.gauge("k8s_cluster_state", "IONOS Managed Kubernetes cluster state (1 for the current state).", 1,
+			"cluster", status.Cluster.Properties.Name, "id", status.Cluster.ID, "version", status.Cluster.Properties.K8sVersion, "state", status.Cluster.Metadata.State)
+	}
+
+	for _, status := range report.Clusters {
+		for _, np := range status.NodePools {
+			m.gauge("k8s_nodepool_nodes", "Configured node count per IONOS Managed Kubernetes node pool.", float64(np.Properties.NodeCount),
+				"cluster", status.Cluster.Properties.Name, "nodepool", np.Properties.Name, "id", np.ID, "state", np.Metadata.State)
+		}
+	}
+}
+
+func writeDBaaSMetrics(m *metricsWriter, report *Report) {
+	if report.DBaaS == nil {
+		return
+	}
+
+	const help = "IONOS DBaaS cluster state per engine (1 for the current state)."
+	for _, cluster := range report.DBaaS.PostgreSQL {
+		m.gauge("dbaas_cluster_state", help, 1, "engine", "postgresql", "cluster", cluster.Properties.DisplayName, "id", cluster.ID, "state", cluster.Metadata.State)
+	}
+	for _, cluster := range report.DBaaS.MongoDB {
+		m.gauge("dbaas_cluster_state", help, 1, "engine", "mongodb", "cluster", cluster.Properties.DisplayName, "id", cluster.ID, "state", cluster.Metadata.State)
+	}
+	for _, cluster := range report.DBaaS.MariaDB {
+		m.gauge("dbaas_cluster_state", help, 1, "engine", "mariadb", "cluster", cluster.Properties.DisplayName, "id", cluster.ID, "state", cluster.Metadata.State)
+	}
+	for _, instance := range report.DBaaS.InMemoryDB {
+		m.gauge("dbaas_cluster_state", help, 1, "engine", "inmemorydb", "cluster", instance.Properties.DisplayName, "id", instance.ID, "state", instance.Metadata.State)
+	}
+}
+
+func writeHealthMetrics(m *metricsWriter, report *Report) {
+	if report.Health == nil {
+		return
+	}
+
+	health := report.Health
+
+	m.gauge("k8s_nodes_total", "Total number of Kubernetes nodes.", float64(health.Nodes.Total))
+	m.gauge("k8s_nodes_ready", "Number of Ready Kubernetes nodes.", float64(health.Nodes.Ready))
+	m.gauge("k8s_node_conditions", "Number of active node pressure conditions.", float64(len(health.Nodes.Conditions)))
+
+	m.gauge("k8s_pods_total", "Total number of pods.", float64(health.Pods.Total))
+	m.gauge("k8s_pods_running", "Number of healthy running pods.", float64(health.Pods.Running))
+	const podHelp = "Number of pods per failure class."
+	m.gauge("k8s_pods_failing", podHelp, float64(len(health.Pods.CrashLoopBackOff)), "class", "crashloopbackoff")
+	m.gauge("k8s_pods_failing", podHelp, float64(len(health.Pods.ImagePullBackOff)), "class", "imagepullbackoff")
+	m.gauge("k8s_pods_failing", podHelp, float64(len(health.Pods.Pending)), "class", "pending")
+	m.gauge("k8s_pods_failing", podHelp, float64(len(health.Pods.Failed)), "class", "failed")
+	m.gauge("k8s_pods_failing", podHelp, float64(len(health.Pods.NotReady)), "class", "notready")
+	m.gauge("k8s_pods_failing", podHelp, float64(len(health.Pods.InitFailed)), "class", "initfailed")
+	m.gauge("k8s_pods_failing", podHelp, float64(len(health.Pods.Restarts)), "class", "restarts")
+	m.gauge("k8s_pods_failing", podHelp, float64(len(health.Pods.OOMKilled)), "class", "oomkilled")
+	m.gauge("k8s_pods_failing", podHelp, float64(len(health.Pods.Terminating)), "class", "terminating")
+	m.gauge("k8s_pods_failing", podHelp, float64(evictedPods(health.Pods.Evicted)), "class", "evicted")
+	m.gauge("k8s_pods_failing", podHelp, float64(len(health.Pods.Unknown)), "class", "unknown")
+
+	m.gauge("k8s_deployments_total", "Total number of deployments.", float64(health.Deployments.Total))
+	m.gauge("k8s_deployments_available", "Number of fully available deployments.", float64(health.Deployments.Available))
+
+	m.gauge("k8s_statefulsets_total", "Total number of statefulsets.", float64(health.StatefulSets.Total))
+	m.gauge("k8s_statefulsets_ready", "Number of statefulsets with all replicas ready.", float64(health.StatefulSets.Ready))
+	m.gauge("k8s_statefulsets_rollout_stuck", "Number of statefulsets with a stuck rolling update.", float64(len(health.StatefulSets.RolloutStuck)))
+
+	m.gauge("k8s_daemonsets_total", "Total number of daemonsets.", float64(health.DaemonSets.Total))
+	m.gauge("k8s_daemonsets_ready", "Number of daemonsets ready on all desired nodes.", float64(health.DaemonSets.Ready))
+	m.gauge("k8s_daemonsets_misscheduled", "Number of daemonsets with misscheduled pods.", float64(len(health.DaemonSets.Misscheduled)))
+
+	m.gauge("k8s_jobs_total", "Total number of jobs.", float64(health.Jobs.Total))
+	m.gauge("k8s_jobs_failed", "Number of failed jobs.", float64(len(health.Jobs.Failed)))
+	m.gauge("k8s_cronjobs_total", "Total number of cronjobs.", float64(health.CronJobs.Total))
+	m.gauge("k8s_cronjobs_missed", "Number of cronjobs without a successful run since their last scheduled run.", float64(len(health.CronJobs.Missed)))
+	m.gauge("k8s_cronjobs_suspended", "Number of suspended cronjobs.", float64(len(health.CronJobs.Suspended)))
+
+	m.gauge("k8s_hpas_total", "Total number of horizontal pod autoscalers.", float64(health.HPAs.Total))
+	m.gauge("k8s_hpas_at_max", "Number of horizontal pod autoscalers at their maximum replicas.", float64(len(health.HPAs.AtMax)))
+	m.gauge("k8s_hpas_scaling_inactive", "Number of horizontal pod autoscalers that cannot compute a replica count.", float64(len(health.HPAs.ScalingInactive)))
+	m.gauge("k8s_pdbs_total", "Total number of pod disruption budgets.", float64(health.PDBs.Total))
+	m.gauge("k8s_pdbs_blocking", "Number of pod disruption budgets that allow no disruptions.", float64(len(health.PDBs.Blocking)))
+
+	m.gauge("k8s_pvcs_total", "Total number of persistent volume claims.", float64(health.PVCs.Total))
+	m.gauge("k8s_pvcs_bound", "Number of bound persistent volume claims.", float64(health.PVCs.Bound))
+
+	m.gauge("k8s_loadbalancers_total", "Total number of LoadBalancer services.", float64(health.Services.Total))
+	m.gauge("k8s_loadbalancers_ready", "Number of LoadBalancer services with an ingress IP.", float64(health.Services.Ready))
+
+	m.gauge("k8s_services_total", "Total number of services with a selector.", float64(health.Endpoints.Total))
+	m.gauge("k8s_services_ready", "Number of services with at least one ready endpoint.", float64(health.Endpoints.Ready))
+	m.gauge("k8s_services_no_endpoints", "Number of services without ready endpoints.", float64(len(health.Endpoints.NoEndpoints)))
+	m.gauge("k8s_ingresses_total", "Total number of ingresses.", float64(health.Endpoints.Ingresses))
+	m.gauge("k8s_ingresses_ready", "Number of ingresses whose backend services all have ready endpoints.", float64(health.Endpoints.IngressesReady))
+	m.gauge("k8s_ingresses_no_backends", "Number of ingresses with backend services without ready endpoints.", float64(len(health.Endpoints.IngressNoBackends)))
+
+	m.gauge("k8s_warning_events", "Number of warning events in the lookback window.", float64(len(health.Events.Warnings)))
+
+	m.gauge("certificates_total", "Total number of TLS certificates referenced by ingresses.", float64(health.Certs.Total))
+	m.gauge("certificates_valid", "Number of TLS certificates valid for more than 30 days.", float64(health.Certs.Valid))
+	const certHelp = "Days until the TLS certificate expires (negative if already expired)."
+	for _, cert := range health.Certs.Expired {
+		m.gauge("certificate_expiry_days", certHelp, float64(cert.ExpiresIn), "namespace", cert.Namespace, "secret", cert.Secret, "host", cert.Host)
+	}
+	for _, cert := range health.Certs.Expiring {
+		m.gauge("certificate_expiry_days", certHelp, float64(cert.ExpiresIn), "namespace", cert.Namespace, "secret", cert.Secret, "host", cert.Host)
+	}
+}
+
+func sortedKeys(m map[string]int) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+type RunStats struct {
+	LastRun  time.Time
+	Duration time.Duration
+	Success  bool
+	Runs     int
+	Failures int
+}
+
+func WriteRunMetrics(w io.Writer, stats RunStats) {
+	m := &metricsWriter{w: w, written: make(map[string]bool)}
+
+	m.family("runs_total", "counter", "Total number of check runs.")
+	m.sample("runs_total", float64(stats.Runs))
+	m.family("run_failures_total", "counter", "Total number of check runs that returned an error.")
+	m.sample("run_failures_total", float64(stats.Failures))
+
+	if stats.LastRun.IsZero() {
+		return
+	}
+
+	m.gauge("last_run_timestamp_seconds", "Unix time of the last completed check run.", float64(stats.LastRun.Unix()))
+	m.gauge("last_run_duration_seconds", "Duration of the last check run.", stats.Duration.Seconds())
+	m.gauge("last_run_success", "Whether the last check run completed without error.", boolValue(stats.Success))
+}
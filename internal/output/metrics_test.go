@@ -0,0 +1,147 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/peterpisarcik/ionos-cloud-watchdog/internal/feed"
+	"github.com/peterpisarcik/ionos-cloud-watchdog/internal/ionos"
+	"github.com/peterpisarcik/ionos-cloud-watchdog/internal/k8s"
+)
+
+func TestWriteMetrics(t *testing.T) {
+	report := &Report{
+		Status: "WARNING",
+		StatusPage: &feed.StatusResult{
+			Status:          feed.StatusWarning,
+			ActiveIncidents: []feed.Entry{{Title: "Incident A"}},
+		},
+		APICheck:  &ionos.CheckResult{OK: true},
+		AuthCheck: &ionos.CheckResult{OK: false},
+		Datacenters: []ionos.DatacenterStatus{{
+			Datacenter: ionos.DataCenter{Properties: struct {
+				Name     string "json:\"name\""
+				Location string "json:\"location\""
+			}{Name: "DC \"One\"", Location: "de/fra"}},
+			Servers: []ionos.Server{
+				{Metadata: struct {
+					State string "json:\"state\""
+				}{State: "AVAILABLE"}},
+				{Metadata: struct {
+					State string "json:\"state\""
+				}{State: "AVAILABLE"}},
+			},
+		}},
+		DBaaS: &ionos.DBaaSStatus{
+			PostgreSQL: []ionos.PostgreSQLCluster{{
+				Properties: struct {
+					DisplayName     string `json:"displayName"`
+					PostgresVersion string `json:"postgresVersion"`
+					Location        string `json:"location"`
+					Instances       int    `json:"instances"`
+				}{DisplayName: "pg"},
+				Metadata: struct {
+					State string `json:"state"`
+				}{State: "AVAILABLE"},
+			}},
+		},
+		Health: &k8s.HealthResult{
+			Nodes: k8s.NodeResult{Total: 3, Ready: 2},
+			Pods:  k8s.PodResult{Total: 5, Running: 3, Pending: []string{"ns/a", "ns/b"}},
+			Certs: k8s.CertResult{
+				Total:    1,
+				Expiring: []k8s.CertInfo{{Host: "soon.example.com", Namespace: "ns", Secret: "tls", ExpiresIn: 7}},
+			},
+		},
+		Issues: []Issue{
+			{Severity: SeverityWarning, Message: "one"},
+			{Severity: SeverityWarning, Message: "two"},
+		},
+	}
+
+	var buf bytes.Buffer
+	WriteMetrics(&buf, report)
+	out := buf.String()
+
+	expectContains(t, out, "# TYPE ionos_watchdog_status gauge")
+	expectContains(t, out, `ionos_watchdog_status{status="WARNING"} 1`)
+	expectContains(t, out, `ionos_watchdog_status{status="OK"} 0`)
+	expectContains(t, out, `ionos_watchdog_issues{severity="warning"} 2`)
+	expectContains(t, out, `ionos_watchdog_issues{severity="critical"} 0`)
+	expectContains(t, out, "ionos_watchdog_statuspage_active_incidents 1")
+	expectContains(t, out, "ionos_watchdog_api_up 1")
+	expectContains(t, out, "ionos_watchdog_auth_ok 0")
+	expectContains(t, out, `ionos_watchdog_datacenter_servers{datacenter="DC \"One\"",id="",state="AVAILABLE"} 2`)
+	expectContains(t, out, `ionos_watchdog_dbaas_cluster_state{engine="postgresql",cluster="pg",id="",state="AVAILABLE"} 1`)
+	expectContains(t, out, "ionos_watchdog_k8s_nodes_ready 2")
+	expectContains(t, out, `ionos_watchdog_k8s_pods_failing{class="pending"} 2`)
+	expectContains(t, out, `ionos_watchdog_certificate_expiry_days{namespace="ns",secret="tls",host="soon.example.com"} 7`)
+
+	if bytes.Count(buf.Bytes(), []byte("# TYPE ionos_watchdog_k8s_pods_failing")) != 1 {
+		t.Fatalf("expected a single TYPE line per metric family\n%s", out)
+	}
+}
+
+func TestWriteMetrics_DistinguishesResourcesWithTheSameName(t *testing.T) {
+	report := &Report{Status: "OK"}
+	for _, id := range []string{"dc-1", "dc-2"} {
+		dc := ionos.DataCenter{ID: id}
+		dc.Properties.Name = "prod"
+		report.Datacenters = append(report.Datacenters, ionos.DatacenterStatus{Datacenter: dc, Servers: make([]ionos.Server, 1)})
+	}
+	for _, id := range []string{"k8s-1", "k8s-2"} {
+		cluster := ionos.K8sCluster{ID: id}
+		cluster.Properties.Name = "prod"
+		cluster.Metadata.State = "ACTIVE"
+		np := ionos.K8sNodePool{ID: "np-" + id}
+		np.Properties.Name = "default"
+		report.Clusters = append(report.Clusters, ionos.K8sClusterStatus{Cluster: cluster, NodePools: []ionos.K8sNodePool{np}})
+	}
+
+	var buf bytes.Buffer
+	WriteMetrics(&buf, report)
+	out := buf.String()
+
+	expectContains(t, out, `ionos_watchdog_datacenter_issues{datacenter="prod",id="dc-1",location=""} 0`)
+	expectContains(t, out, `ionos_watchdog_datacenter_issues{datacenter="prod",id="dc-2",location=""} 0`)
+	expectContains(t, out, `ionos_watchdog_k8s_cluster_state{cluster="prod",id="k8s-2",version="",state="ACTIVE"} 1`)
+	expectContains(t, out, `ionos_watchdog_k8s_nodepool_nodes{cluster="prod",nodepool="default",id="np-k8s-2",state=""} 0`)
+
+	series := make(map[string]bool)
+	for _, line := range strings.Split(out, "\n") {
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		name := line[:strings.LastIndex(line, " ")]
+		if series[name] {
+			t.Fatalf("duplicate series %s\n%s", name, out)
+		}
+		series[name] = true
+	}
+}
+
+func TestWriteRunMetrics(t *testing.T) {
+	var buf bytes.Buffer
+	WriteRunMetrics(&buf, RunStats{})
+	expectContains(t, buf.String(), "ionos_watchdog_runs_total 0")
+	if bytes.Contains(buf.Bytes(), []byte("last_run_timestamp_seconds")) {
+		t.Fatalf("expected no last run metrics before the first run\n%s", buf.String())
+	}
+
+	buf.Reset()
+	WriteRunMetrics(&buf, RunStats{
+		LastRun:  time.Unix(1700000000, 0),
+		Duration: 1500 * time.Millisecond,
+		Success:  true,
+		Runs:     3,
+		Failures: 1,
+	})
+	out := buf.String()
+	expectContains(t, out, "ionos_watchdog_runs_total 3")
+	expectContains(t, out, "ionos_watchdog_run_failures_total 1")
+	expectContains(t, out, "ionos_watchdog_last_run_timestamp_seconds 1.7e+09")
+	expectContains(t, out, "ionos_watchdog_last_run_duration_seconds 1.5")
+	expectContains(t, out, "ionos_watchdog_last_run_success 1")
+}
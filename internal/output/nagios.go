@@ -0,0 +1,125 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+const nagiosService = "IONOS WATCHDOG"
+
+// WriteNagios prints the report in the Nagios/Icinga plugin format: a status
+// line with performance data, followed by one line of long output per issue.
+func WriteNagios(w io.Writer, report *Report) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %s - %s | %s\n", nagiosService, report.Status, nagiosSummary(report), strings.Join(nagiosPerfData(report), " "))
+	for _, issue := range report.Issues {
+		fmt.Fprintf(&b, "[%s] %s\n", strings.ToUpper(string(issue.Severity)), nagiosText(issue.Message))
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteNagiosUnknown reports a check run that could not complete.
+func WriteNagiosUnknown(w io.Writer, err error) error {
+	_, werr := fmt.Fprintf(w, "%s UNKNOWN - %s\n", nagiosService, nagiosText(err.Error()))
+	return werr
+}
+
+func nagiosSummary(report *Report) string {
+	if len(report.Issues) == 0 {
+		return "no issues"
+	}
+
+	counts := make(map[Severity]int)
+	for _, issue := range report.Issues {
+		counts[issue.Severity]++
+	}
+	var parts []string
+	for _, severity := range []Severity{SeverityCritical, SeverityWarning, SeverityInfo} {
+		if counts[severity] > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", counts[severity], severity))
+		}
+	}
+	return strings.Join(parts, ", ") + ": " + nagiosText(report.Issues[0].Message)
+}
+
+// nagiosText removes characters with a special meaning in plugin output.
+func nagiosText(s string) string {
+	return strings.NewReplacer("|", "/", "\n", " ").Replace(s)
+}
+
+func nagiosPerfData(report *Report) []string {
+	var perf []string
+	add := func(label string, value int, suffix string) {
+		perf = append(perf, fmt.Sprintf("%s=%d%s", label, value, suffix))
+	}
+
+	counts := make(map[Severity]int)
+	for _, issue := range report.Issues {
+		counts[issue.Severity]++
+	}
+	add("issues_critical", counts[SeverityCritical], ";;;0")
+	add("issues_warning", counts[SeverityWarning], ";;;0")
+
+	if report.APICheck != nil {
+		add("api_up", int(boolValue(report.APICheck.OK)), ";;;0;1")
+	}
+
+	if report.Datacenters != nil {
+		servers, volumesUnavailable := 0, 0
+		for _, status := range report.Datacenters {
+			servers += len(status.Servers)
+			for _, vol := range status.Volumes {
+				if vol.Metadata.State != "AVAILABLE" {
+					volumesUnavailable++
+				}
+			}
+		}
+		add("datacenters", len(report.Datacenters), ";;;0")
+		add("servers", servers, ";;;0")
+		add("volumes_unavailable", volumesUnavailable, ";;;0")
+	}
+
+	if report.Clusters != nil {
+		add("k8s_clusters_with_issues", countClusterIssues(report), ";;;0")
+	}
+
+	if health := report.Health; health != nil {
+		pods := health.Pods
+		add("nodes_ready", health.Nodes.Ready, fmt.Sprintf(";;;0;%d", health.Nodes.Total))
+		add("nodes_not_ready", len(health.Nodes.NotReady), ";;;0")
+		add("pods_running", pods.Running, fmt.Sprintf(";;;0;%d", pods.Total))
+		add("pods_failing", len(pods.CrashLoopBackOff)+len(pods.ImagePullBackOff)+len(pods.Failed)+len(pods.NotReady)+len(pods.InitFailed)+
+			len(pods.Terminating)+evictedPods(pods.Evicted)+len(pods.Unknown), ";;;0")
+		add("pods_restarting", len(pods.Restarts)+len(pods.OOMKilled), ";;;0")
+		add("pods_pending", len(pods.Pending), ";;;0")
+		add("deployments_unavailable", len(health.Deployments.Unavailable), ";;;0")
+		add("statefulsets_unavailable", len(health.StatefulSets.Unavailable)+len(health.StatefulSets.RolloutStuck), ";;;0")
+		add("daemonsets_unavailable", len(health.DaemonSets.Unavailable), ";;;0")
+		add("jobs_failed", len(health.Jobs.Failed), ";;;0")
+		add("cronjobs_missed", len(health.CronJobs.Missed), ";;;0")
+		add("hpas_at_max", len(health.HPAs.AtMax), ";;;0")
+		add("hpas_scaling_inactive", len(health.HPAs.ScalingInactive), ";;;0")
+		add("pdbs_blocking", len(health.PDBs.Blocking), ";;;0")
+		add("pvcs_pending", len(health.PVCs.Pending), ";;;0")
+		add("loadbalancers_no_ip", len(health.Services.NoIP), ";;;0")
+		add("services_no_endpoints", len(health.Endpoints.NoEndpoints), ";;;0")
+		add("ingresses_no_backends", len(health.Endpoints.IngressNoBackends), ";;;0")
+		if health.Certs.Total > 0 {
+			add("cert_min_days", health.Certs.MinExpiresIn, ";30:;0:")
+		}
+	}
+
+	return perf
+}
+
+func countClusterIssues(report *Report) int {
+	count := 0
+	for _, status := range report.Clusters {
+		if len(status.Issues) > 0 {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,69 @@
+package output
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/peterpisarcik/ionos-cloud-watchdog/internal/config"
+	"github.com/peterpisarcik/ionos-cloud-watchdog/internal/ionos"
+	"github.com/peterpisarcik/ionos-cloud-watchdog/internal/k8s"
+)
+
+func TestWriteNagios_StatusLinePerfDataAndLongOutput(t *testing.T) {
+	report := &Report{
+		Status:   "CRITICAL",
+		APICheck: &ionos.CheckResult{OK: true},
+		Health: &k8s.HealthResult{
+			Nodes: k8s.NodeResult{Total: 3, Ready: 2, NotReady: []string{"node-3"}},
+			Pods:  k8s.PodResult{Total: 10, Running: 8, CrashLoopBackOff: []string{"ns/a"}, Pending: []string{"ns/b"}},
+			Certs: k8s.CertResult{Total: 2, Valid: 1, MinExpiresIn: 12},
+		},
+		Issues: []Issue{
+			{Severity: SeverityCritical, Message: "Node node-3 NotReady"},
+			{Severity: SeverityWarning, Message: "Pod ns/a CrashLoopBackOff | restarted"},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteNagios(&buf, report); err != nil {
+		t.Fatalf("WriteNagios returned error: %v", err)
+	}
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+
+	status, perf, ok := strings.Cut(lines[0], " | ")
+	if !ok || status != "IONOS WATCHDOG CRITICAL - 1 critical, 1 warning: Node node-3 NotReady" {
+		t.Fatalf("unexpected status line: %s", lines[0])
+	}
+	for _, want := range []string{"issues_critical=1;;;0", "api_up=1;;;0;1", "nodes_ready=2;;;0;3", "pods_failing=1;;;0", "pods_pending=1;;;0", "cert_min_days=12;30:;0:"} {
+		if !strings.Contains(" "+perf+" ", " "+want+" ") {
+			t.Fatalf("expected perfdata %q in %q", want, perf)
+		}
+	}
+
+	if len(lines) != 3 || lines[2] != "[WARNING] Pod ns/a CrashLoopBackOff / restarted" {
+		t.Fatalf("unexpected long output: %q", lines[1:])
+	}
+}
+
+func TestWriteNagios_OK(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteNagios(&buf, &Report{Status: "OK"}); err != nil {
+		t.Fatalf("WriteNagios returned error: %v", err)
+	}
+	if buf.String() != "IONOS WATCHDOG OK - no issues | issues_critical=0;;;0 issues_warning=0;;;0\n" {
+		t.Fatalf("unexpected output: %q", buf.String())
+	}
+}
+
+func TestWriteNagiosUnknown(t *testing.T) {
+	var buf bytes.Buffer
+	_ = WriteNagiosUnknown(&buf, errors.New("context canceled"))
+	if buf.String() != "IONOS WATCHDOG UNKNOWN - context canceled\n" {
+		t.Fatalf("unexpected output: %q", buf.String())
+	}
+	if code := ExitCode("UNKNOWN", config.PolicyConfig{}); code != 3 {
+		t.Fatalf("expected exit code 3, got %d", code)
+	}
+}
@@ -0,0 +1,113 @@
+package output
+
+import (
+	"path"
+	"strings"
+
+	"github.com/peterpisarcik/ionos-cloud-watchdog/internal/config"
+)
+
+const (
+	defaultWarningThreshold  = 1
+	defaultCriticalThreshold = 4
+)
+
+var (
+	defaultWeights   = map[Severity]int{SeverityInfo: 0, SeverityWarning: 1, SeverityCritical: 4}
+	defaultExitCodes = map[string]int{"OK": 0, "WARNING": 1, "CRITICAL": 2, "UNKNOWN": 3}
+)
+
+func applyPolicy(issues []Issue, policy config.PolicyConfig) []Issue {
+	if len(policy.Rules) == 0 {
+		return issues
+	}
+
+	result := make([]Issue, 0, len(issues))
+	for _, issue := range issues {
+		if rule, ok := matchRule(issue, policy.Rules); ok {
+			if rule.Severity == "ignore" {
+				continue
+			}
+			issue.Severity = Severity(rule.Severity)
+		}
+		result = append(result, issue)
+	}
+	return result
+}
+
+func matchRule(issue Issue, rules []config.SeverityRule) (config.SeverityRule, bool) {
+	for _, rule := range rules {
+		if matchPattern(rule.Check, issue.Check) &&
+			matchPattern(rule.Subsystem, issue.Subsystem) &&
+			matchPattern(rule.Kind, issue.Resource.Kind) &&
+			matchPattern(rule.Namespace, issue.Resource.Namespace) &&
+			matchPattern(rule.Name, issue.Resource.Name) {
+			return rule, true
+		}
+	}
+	return config.SeverityRule{}, false
+}
+
+// matchPattern treats an empty pattern as a wildcard and otherwise uses glob syntax.
+func matchPattern(pattern, value string) bool {
+	if pattern == "" {
+		return true
+	}
+	ok, err := path.Match(pattern, value)
+	return err == nil && ok
+}
+
+func evaluateStatus(issues []Issue, policy config.PolicyConfig) string {
+	switch policy.Mode {
+	case config.PolicyModeCount:
+		count := 0
+		for _, issue := range issues {
+			if issue.Severity.rank() > 0 {
+				count++
+			}
+		}
+		return statusFromScore(count, policy)
+	case config.PolicyModeWeighted:
+		score := 0
+		for _, issue := range issues {
+			score += weight(issue.Severity, policy)
+		}
+		return statusFromScore(score, policy)
+	default:
+		return statusFromIssues(issues)
+	}
+}
+
+func statusFromScore(score int, policy config.PolicyConfig) string {
+	warning := policy.WarningThreshold
+	if warning == 0 {
+		warning = defaultWarningThreshold
+	}
+	critical := policy.CriticalThreshold
+	if critical == 0 {
+		critical = defaultCriticalThreshold
+	}
+
+	switch {
+	case score >= critical:
+		return "CRITICAL"
+	case score >= warning:
+		return "WARNING"
+	default:
+		return "OK"
+	}
+}
+
+func weight(severity Severity, policy config.PolicyConfig) int {
+	if w, ok := policy.Weights[string(severity)]; ok {
+		return w
+	}
+	return defaultWeights[severity]
+}
+
+func ExitCode(status string, policy config.PolicyConfig) int {
+	if code, ok := policy.ExitCodes[strings.ToLower(status)]; ok {
+		return code
+	}
+	return defaultExitCodes[status]
+}
@@ -0,0 +1,119 @@
+package output
+
+import (
+	"context"
+	"testing"
+
+	"github.com/peterpisarcik/ionos-cloud-watchdog/internal/config"
+	"github.com/peterpisarcik/ionos-cloud-watchdog/internal/feed"
+	"github.com/peterpisarcik/ionos-cloud-watchdog/internal/ionos"
+	"github.com/peterpisarcik/ionos-cloud-watchdog/internal/k8s"
+)
+
+func TestApplyPolicy_RulesOverrideSeverity(t *testing.T) {
+	issues := []Issue{
+		{Severity: SeverityWarning, Check: CheckPods, Resource: Resource{Kind: "Pod", Namespace: "batch", Name: "job-1"}, Message: "pending batch pod"},
+		{Severity: SeverityWarning, Check: CheckPods, Resource: Resource{Kind: "Pod", Namespace: "web", Name: "api-1"}, Message: "pending web pod"},
+		{Severity: SeverityWarning, Check: CheckCertificates, Resource: Resource{Kind: "Secret", Namespace: "web", Name: "tls"}, Message: "cert expiring"},
+		{Severity: SeverityWarning, Check: CheckStatusPage, Message: "incident"},
+	}
+
+	policy := config.PolicyConfig{
+		Rules: []config.SeverityRule{
+			{Check: "k8s.pods", Namespace: "batch", Severity: "ignore"},
+			{Check: "k8s.*", Namespace: "web", Name: "api-*", Severity: "critical"},
+			{Subsystem: "", Check: "statuspage.*", Severity: "info"},
+		},
+	}
+
+	result := applyPolicy(issues, policy)
+
+	if len(result) != 3 {
+		t.Fatalf("expected ignored issue to be dropped, got %d issues", len(result))
+	}
+	if result[0].Message != "pending web pod" || result[0].Severity != SeverityCritical {
+		t.Fatalf("expected web pod to be critical, got %+v", result[0])
+	}
+	if result[1].Severity != SeverityWarning {
+		t.Fatalf("expected unmatched issue to keep its severity, got %+v", result[1])
+	}
+	if result[2].Severity != SeverityInfo {
+		t.Fatalf("expected status page issue to be info, got %+v", result[2])
+	}
+}
+
+func TestEvaluateStatus_Modes(t *testing.T) {
+	warnings := func(n int) []Issue {
+		issues := make([]Issue, n)
+		for i := range issues {
+			issues[i] = Issue{Severity: SeverityWarning}
+		}
+		return issues
+	}
+	critical := []Issue{{Severity: SeverityCritical}}
+	infos := []Issue{{Severity: SeverityInfo}, {Severity: SeverityInfo}}
+
+	tests := []struct {
+		name     string
+		policy   config.PolicyConfig
+		issues   []Issue
+		expected string
+	}{
+		{"worst: no issues", config.PolicyConfig{}, nil, "OK"},
+		{"worst: info only", config.PolicyConfig{}, infos, "OK"},
+		{"worst: many warnings", config.PolicyConfig{}, warnings(10), "WARNING"},
+		{"worst: single critical", config.PolicyConfig{Mode: "worst"}, critical, "CRITICAL"},
+		{"count: defaults warning", config.PolicyConfig{Mode: "count"}, warnings(3), "WARNING"},
+		{"count: defaults critical", config.PolicyConfig{Mode: "count"}, warnings(4), "CRITICAL"},
+		{"count: ignores info", config.PolicyConfig{Mode: "count"}, infos, "OK"},
+		{"count: custom thresholds", config.PolicyConfig{Mode: "count", WarningThreshold: 2, CriticalThreshold: 10}, warnings(1), "OK"},
+		{"weighted: critical weight", config.PolicyConfig{Mode: "weighted"}, critical, "CRITICAL"},
+		{"weighted: warnings add up", config.PolicyConfig{Mode: "weighted"}, warnings(4), "CRITICAL"},
+		{"weighted: custom weights", config.PolicyConfig{Mode: "weighted", Weights: map[string]int{"critical": 2}}, critical, "WARNING"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := evaluateStatus(tt.issues, tt.policy); got != tt.expected {
+				t.Errorf("evaluateStatus() = %s, want %s", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestExitCode(t *testing.T) {
+	if code := ExitCode("CRITICAL", config.PolicyConfig{}); code != 2 {
+		t.Fatalf("expected default critical exit code 2, got %d", code)
+	}
+	if code := ExitCode("OK", config.PolicyConfig{}); code != 0 {
+		t.Fatalf("expected default OK exit code 0, got %d", code)
+	}
+
+	policy := config.PolicyConfig{ExitCodes: map[string]int{"warning": 0}}
+	if code := ExitCode("WARNING", policy); code != 0 {
+		t.Fatalf("expected overridden warning exit code 0, got %d", code)
+	}
+	if code := ExitCode("CRITICAL", policy); code != 2 {
+		t.Fatalf("expected default critical exit code 2, got %d", code)
+	}
+}
+
+func TestRunChecks_AppliesPolicy(t *testing.T) {
+	restore := stubDependencies(t, &dependencyStubs{
+		feedResult:  &feed.StatusResult{Status: feed.StatusOK},
+		ionosClient: &fakeIONOSClient{connectivity: ionos.CheckResult{OK: true}, auth: ionos.CheckResult{OK: false}},
+		k8sHealth:   &k8s.HealthResult{},
+	})
+	defer restore()
+
+	report, err := RunChecks(context.Background(), Options{Policy: config.PolicyConfig{
+		Rules: []config.SeverityRule{{Check: CheckAuth, Severity: "warning"}},
+	}})
+	if err != nil {
+		t.Fatalf("RunChecks returned error: %v", err)
+	}
+
+	if report.Status != "WARNING" {
+		t.Fatalf("expected policy to downgrade status to WARNING, got %s", report.Status)
+	}
+}
@@ -0,0 +1,291 @@
+package output
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/peterpisarcik/ionos-cloud-watchdog/internal/ionos"
+	"github.com/peterpisarcik/ionos-cloud-watchdog/internal/k8s"
+)
+
+// service is a unit that monitoring systems track individually, such as a
+// datacenter or a Kubernetes check category. Issues go to the first service
+// matching them, or to the fallback service of their subsystem.
+type service struct {
+	ID      string
+	Name    string
+	Group   string
+	Summary string
+	Metrics []serviceMetric
+	Issues  []Issue
+
+	match func(Issue) bool
+	// fallback services collect the issues of their group that no other
+	// service matched, e.g. timeouts.
+	fallback bool
+	// optional services are only reported when they received an issue.
+	optional bool
+}
+
+type serviceMetric struct {
+	Name  string
+	Value int
+}
+
+func (s *service) Status() string {
+	return statusFromIssues(s.Issues)
+}
+
+// Text is the summary followed by the messages of all issues.
+func (s *service) Text() string {
+	if len(s.Issues) == 0 {
+		return s.Summary
+	}
+	messages := make([]string, 0, len(s.Issues))
+	for _, issue := range s.Issues {
+		messages = append(messages, issue.Message)
+	}
+	text := strings.Join(messages, "; ")
+	if s.Summary != "" {
+		text = s.Summary + ", " + text
+	}
+	return text
+}
+
+type k8sCategory struct {
+	id      string
+	name    string
+	checks  []string
+	summary func(*k8s.HealthResult) (string, []serviceMetric)
+}
+
+var k8sCategories = []k8sCategory{
+	{"nodes", "Nodes", []string{CheckNodes, CheckNodeConditions}, func(h *k8s.HealthResult) (string, []serviceMetric) {
+		return ratio(h.Nodes.Ready, h.Nodes.Total, "ready")
+	}},
+	{"pods", "Pods", []string{CheckPods, CheckPodRestarts}, func(h *k8s.HealthResult) (string, []serviceMetric) {
+		return ratio(h.Pods.Running, h.Pods.Total, "running")
+	}},
+	{"deployments", "Deployments", []string{CheckDeployments}, func(h *k8s.HealthResult) (string, []serviceMetric) {
+		return ratio(h.Deployments.Available, h.Deployments.Total, "available")
+	}},
+	{"statefulsets", "StatefulSets", []string{CheckStatefulSets}, func(h *k8s.HealthResult) (string, []serviceMetric) {
+		return ratio(h.StatefulSets.Ready, h.StatefulSets.Total, "ready")
+	}},
+	{"daemonsets", "DaemonSets", []string{CheckDaemonSets}, func(h *k8s.HealthResult) (string, []serviceMetric) {
+		return ratio(h.DaemonSets.Ready, h.DaemonSets.Total, "ready")
+	}},
+	{"jobs", "Jobs", []string{CheckJobs}, func(h *k8s.HealthResult) (string, []serviceMetric) {
+		return fmt.Sprintf("%d/%d jobs failed", len(h.Jobs.Failed), h.Jobs.Total),
+			[]serviceMetric{{"failed", len(h.Jobs.Failed)}, {"total", h.Jobs.Total}}
+	}},
+	{"cronjobs", "CronJobs", []string{CheckCronJobs}, func(h *k8s.HealthResult) (string, []serviceMetric) {
+		summary, metrics := ratio(h.CronJobs.OnSchedule, h.CronJobs.Total, "on_schedule")
+		return summary, append(metrics, serviceMetric{"suspended", len(h.CronJobs.Suspended)})
+	}},
+	{"hpas", "HPAs", []string{CheckHPAs}, func(h *k8s.HealthResult) (string, []serviceMetric) {
+		summary, metrics := ratio(h.HPAs.Healthy, h.HPAs.Total, "healthy")
+		return summary, append(metrics, serviceMetric{"at_max", len(h.HPAs.AtMax)})
+	}},
+	{"pdbs", "PDBs", []string{CheckPDBs}, func(h *k8s.HealthResult) (string, []serviceMetric) {
+		summary, metrics := ratio(h.PDBs.Healthy, h.PDBs.Total, "healthy")
+		return summary, append(metrics, serviceMetric{"blocking", len(h.PDBs.Blocking)})
+	}},
+	{"pvcs", "PVCs", []string{CheckPVCs}, func(h *k8s.HealthResult) (string, []serviceMetric) {
+		return ratio(h.PVCs.Bound, h.PVCs.Total, "bound")
+	}},
+	{"loadbalancers", "LoadBalancers", []string{CheckLoadBalancers}, func(h *k8s.HealthResult) (string, []serviceMetric) {
+		return ratio(h.Services.Ready, h.Services.Total, "ready")
+	}},
+	{"endpoints", "Service Endpoints", []string{CheckEndpoints}, func(h *k8s.HealthResult) (string, []serviceMetric) {
+		return ratio(h.Endpoints.Ready, h.Endpoints.Total, "ready")
+	}},
+	{"ingresses", "Ingresses", []string{CheckIngresses}, func(h *k8s.HealthResult) (string, []serviceMetric) {
+		return ratio(h.Endpoints.IngressesReady, h.Endpoints.Ingresses, "ready")
+	}},
+	{"certificates", "Certificates", []string{CheckCertificates}, func(h *k8s.HealthResult) (string, []serviceMetric) {
+		summary, metrics := ratio(h.Certs.Valid, h.Certs.Total, "valid")
+		if h.Certs.Total > 0 {
+			metrics = append(metrics, serviceMetric{"min_days", h.Certs.MinExpiresIn})
+		}
+		return summary, metrics
+	}},
+	{"events", "Warning Events", []string{CheckEvents}, func(h *k8s.HealthResult) (string, []serviceMetric) {
+		count := 0
+		for _, group := range h.Events.Groups {
+			count += group.Count
+		}
+		return fmt.Sprintf("%d warning events on %d objects", count, len(h.Events.Groups)),
+			[]serviceMetric{{"events", count}, {"objects", len(h.Events.Groups)}}
+	}},
+}
+
+func ratio(ok, total int, label string) (string, []serviceMetric) {
+	return fmt.Sprintf("%d/%d %s", ok, total, label), []serviceMetric{{label, ok}, {"total", total}}
+}
+
+func matchCheck(checks ...string) func(Issue) bool {
+	return func(issue Issue) bool {
+		for _, check := range checks {
+			if issue.Check == check {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+func matchResource(kind, id string) func(Issue) bool {
+	return func(issue Issue) bool {
+		if issue.Resource.Parent.Kind == kind {
+			return issue.Resource.Parent.ID == id
+		}
+		return issue.Resource.Kind == kind && issue.Resource.ID == id
+	}
+}
+
+// buildServices splits a report into services. Services of subsystems that
+// were not checked are left out, unless an issue belongs to them. DBaaS is
+// reported per cluster, or per engine if dbaasByEngine is set.
+func buildServices(report *Report, dbaasByEngine bool) []*service {
+	var services []*service
+	add := func(s *service, checked bool) {
+		if checked || s.fallback {
+			s.optional = !checked
+			services = append(services, s)
+		}
+	}
+
+	statusPage := &service{ID: "statuspage", Name: "IONOS Status Page", Group: SubsystemStatusPage, fallback: true}
+	if report.StatusPage != nil {
+		statusPage.Summary = string(report.StatusPage.Status)
+		statusPage.Metrics = []serviceMetric{{"incidents", len(report.StatusPage.ActiveIncidents)}}
+	}
+	add(statusPage, report.StatusPage != nil)
+
+	api := &service{ID: "ionos.api", Name: "IONOS API", Group: SubsystemIONOS, match: matchCheck(CheckAPI), fallback: true}
+	if report.APICheck != nil {
+		api.Summary = report.APICheck.Message
+	}
+	add(api, report.APICheck != nil)
+	add(&service{ID: "ionos.auth", Name: "IONOS Authentication", Group: SubsystemIONOS, match: matchCheck(CheckAuth)}, report.AuthCheck != nil)
+
+	for _, status := range report.Datacenters {
+		dc := status.Datacenter
+		add(&service{
+			ID:      "ionos.datacenter." + dc.ID,
+			Name:    "IONOS Datacenter " + dc.Properties.Name,
+			Group:   SubsystemIONOS,
+			Summary: fmt.Sprintf("%s, %d servers, %d volumes", dc.Properties.Location, len(status.Servers), len(status.Volumes)),
+			Metrics: []serviceMetric{{"servers", len(status.Servers)}, {"volumes", len(status.Volumes)}},
+			match:   matchResource("Datacenter", dc.ID),
+		}, true)
+	}
+
+	for _, status := range report.Clusters {
+		cluster := status.Cluster
+		add(&service{
+			ID:      "ionos.k8s_cluster." + cluster.ID,
+			Name:    "IONOS K8s Cluster " + cluster.Properties.Name,
+			Group:   SubsystemIONOS,
+			Summary: fmt.Sprintf("v%s, %s, %d node pools", cluster.Properties.K8sVersion, cluster.Metadata.State, len(status.NodePools)),
+			Metrics: []serviceMetric{{"node_pools", len(status.NodePools)}},
+			match:   matchResource("K8sCluster", cluster.ID),
+		}, true)
+	}
+
+	if report.DBaaS != nil {
+		if dbaasByEngine {
+			for _, s := range dbaasEngineServices(report.DBaaS) {
+				add(s, true)
+			}
+		} else {
+			for _, cluster := range jsonDBaaS(report.DBaaS).Clusters {
+				add(&service{
+					ID:      "ionos.dbaas." + cluster.Engine + "." + cluster.ID,
+					Name:    fmt.Sprintf("IONOS DBaaS %s %s", cluster.Engine, cluster.Name),
+					Group:   SubsystemIONOS,
+					Summary: fmt.Sprintf("v%s, %s, %s", cluster.Version, cluster.Location, cluster.State),
+					match:   matchResource("DBaaS", cluster.ID),
+				}, true)
+			}
+		}
+	}
+
+	kubernetes := &service{ID: "k8s.api", Name: "Kubernetes API", Group: SubsystemKubernetes, match: matchCheck(CheckK8sHealth), fallback: true}
+	if report.Health != nil {
+		kubernetes.Summary = "reachable"
+	}
+	add(kubernetes, report.Health != nil)
+	for _, category := range k8sCategories {
+		s := &service{ID: "k8s." + category.id, Name: "Kubernetes " + category.name, Group: SubsystemKubernetes, match: matchCheck(category.checks...)}
+		if report.Health != nil {
+			s.Summary, s.Metrics = category.summary(report.Health)
+		}
+		add(s, report.Health != nil)
+	}
+
+	assignIssues(services, report.Issues)
+
+	result := services[:0]
+	for _, s := range services {
+		if s.optional && len(s.Issues) == 0 {
+			continue
+		}
+		result = append(result, s)
+	}
+	return result
+}
+
+func dbaasEngineServices(status *ionos.DBaaSStatus) []*service {
+	engines := []string{ionos.EnginePostgreSQL, ionos.EngineMongoDB, ionos.EngineMariaDB, ionos.EngineInMemoryDB}
+	clusters := make(map[string][]string)
+	for _, cluster := range jsonDBaaS(status).Clusters {
+		clusters[cluster.Engine] = append(clusters[cluster.Engine], cluster.ID)
+	}
+
+	services := make([]*service, 0, len(engines))
+	for _, engine := range engines {
+		ids := clusters[engine]
+		services = append(services, &service{
+			ID:      "ionos.dbaas." + engine,
+			Name:    "IONOS DBaaS " + engine,
+			Group:   SubsystemIONOS,
+			Summary: fmt.Sprintf("%d clusters", len(ids)),
+			Metrics: []serviceMetric{{"clusters", len(ids)}},
+			match: func(issue Issue) bool {
+				if issue.Resource.Kind != "DBaaS" {
+					return false
+				}
+				if issue.Resource.ID == "" {
+					return issue.Resource.Name == engine
+				}
+				return slices.Contains(ids, issue.Resource.ID)
+			},
+		})
+	}
+	return services
+}
+
+func assignIssues(services []*service, issues []Issue) {
+	for _, issue := range issues {
+		if target := findService(services, issue); target != nil {
+			target.Issues = append(target.Issues, issue)
+		}
+	}
+}
+
+func findService(services []*service, issue Issue) *service {
+	for _, s := range services {
+		if s.match != nil && s.match(issue) {
+			return s
+		}
+	}
+	for _, s := range services {
+		if s.fallback && s.Group == issue.Subsystem {
+			return s
+		}
+	}
+	return nil
+}
@@ -2,6 +2,10 @@ package output
 
 import (
 	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
 
 	"github.com/peterpisarcik/ionos-cloud-watchdog/internal/feed"
 	"github.com/peterpisarcik/ionos-cloud-watchdog/internal/ionos"
@@ -17,37 +21,49 @@ type Report struct {
 	Clusters    []ionos.K8sClusterStatus
 	DBaaS       *ionos.DBaaSStatus
 	Health      *k8s.HealthResult
-	Issues      []string
+	Issues      []Issue
+	StartedAt   time.Time
+	FinishedAt  time.Time
 }
 
 type Config struct {
 	Verbose bool
+	// Writer defaults to os.Stdout.
+	Writer io.Writer
 }
 
 func PrintText(report *Report, cfg *Config) {
-	fmt.Println()
-	printIONOSCloud(report)
-	printDatacenters(report, cfg)
-	printClusters(report, cfg)
-	printDBaaS(report, cfg)
-	printHealth(report)
-	printIssues(report)
-	fmt.Println()
-	fmt.Printf("Status: %s\n", report.Status)
+	out := cfg.Writer
+	if out == nil {
+		out = os.Stdout
+	}
+
+	w := &strings.Builder{}
+	fmt.Fprintln(w)
+	printIONOSCloud(w, report)
+	printDatacenters(w, report, cfg)
+	printClusters(w, report, cfg)
+	printDBaaS(w, report, cfg)
+	printHealth(w, report, cfg)
+	printIssues(w, report, cfg)
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "Status: %s\n", report.Status)
+
+	_, _ = io.WriteString(out, w.String())
 }
 
-func printIONOSCloud(report *Report) {
-	fmt.Println("IONOS Cloud")
-	fmt.Println("-----------")
+func printIONOSCloud(w *strings.Builder, report *Report) {
+	fmt.Fprintln(w, "IONOS Cloud")
+	fmt.Fprintln(w, "-----------")
 
 	if report.StatusPage != nil {
 		if report.StatusPage.Status == feed.StatusOK {
-			fmt.Printf("  %-14s %s\n", "Status Page", "OK")
+			fmt.Fprintf(w, "  %-14s %s\n", "Status Page", "OK")
 		} else {
-			fmt.Printf("  %-14s %s\n", "Status Page", report.StatusPage.Status)
+			fmt.Fprintf(w, "  %-14s %s\n", "Status Page", report.StatusPage.Status)
 			if len(report.StatusPage.ActiveIncidents) > 0 {
 				for _, incident := range report.StatusPage.ActiveIncidents {
-					fmt.Printf("    - %s\n", incident.Title)
+					fmt.Fprintf(w, "    - %s\n", incident.Title)
 				}
 			}
 		}
@@ -55,90 +71,90 @@ func printIONOSCloud(report *Report) {
 
 	if report.APICheck != nil {
 		if report.APICheck.OK {
-			fmt.Printf("  %-14s %s\n", "API", "OK")
+			fmt.Fprintf(w, "  %-14s %s\n", "API", "OK")
 		} else {
-			fmt.Printf("  %-14s %s\n", "API", "FAILED")
+			fmt.Fprintf(w, "  %-14s %s\n", "API", "FAILED")
 		}
 	} else {
-		fmt.Printf("  %-14s %s\n", "API", "SKIPPED")
+		fmt.Fprintf(w, "  %-14s %s\n", "API", "SKIPPED")
 	}
 
 	if report.AuthCheck != nil {
 		if report.AuthCheck.OK {
-			fmt.Printf("  %-14s %s\n", "Authentication", "OK")
+			fmt.Fprintf(w, "  %-14s %s\n", "Authentication", "OK")
 		} else {
-			fmt.Printf("  %-14s %s\n", "Authentication", "FAILED")
+			fmt.Fprintf(w, "  %-14s %s\n", "Authentication", "FAILED")
 		}
 	}
 }
 
-func printDatacenters(report *Report, cfg *Config) {
+func printDatacenters(w *strings.Builder, report *Report, cfg *Config) {
 	if len(report.Datacenters) == 0 {
 		return
 	}
 
-	fmt.Println()
-	fmt.Println("Datacenters")
-	fmt.Println("-----------")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Datacenters")
+	fmt.Fprintln(w, "-----------")
 
 	for _, status := range report.Datacenters {
-		fmt.Printf("  %s (%s)\n", status.Datacenter.Properties.Name, status.Datacenter.Properties.Location)
-		fmt.Printf("    Servers: %d\n", len(status.Servers))
+		fmt.Fprintf(w, "  %s (%s)\n", status.Datacenter.Properties.Name, status.Datacenter.Properties.Location)
+		fmt.Fprintf(w, "    Servers: %d\n", len(status.Servers))
 		if cfg.Verbose {
 			for _, srv := range status.Servers {
 				state := srv.Properties.VMState
 				if state == "" {
 					state = srv.Metadata.State
 				}
-				fmt.Printf("      - %s (%s)\n", srv.Properties.Name, state)
+				fmt.Fprintf(w, "      - %s (%s)\n", srv.Properties.Name, state)
 			}
 		}
-		fmt.Printf("    Volumes: %d\n", len(status.Volumes))
+		fmt.Fprintf(w, "    Volumes: %d\n", len(status.Volumes))
 		if cfg.Verbose {
 			for _, vol := range status.Volumes {
-				fmt.Printf("      - %s (%.0fGB %s)\n", vol.Properties.Name, vol.Properties.Size, vol.Properties.Type)
+				fmt.Fprintf(w, "      - %s (%.0fGB %s)\n", vol.Properties.Name, vol.Properties.Size, vol.Properties.Type)
 			}
 		}
 		if len(status.Issues) == 0 {
-			fmt.Println("    State: OK")
+			fmt.Fprintln(w, "    State: OK")
 		} else {
-			fmt.Println("    State: ISSUES")
+			fmt.Fprintln(w, "    State: ISSUES")
 		}
 	}
 }
 
-func printClusters(report *Report, cfg *Config) {
+func printClusters(w *strings.Builder, report *Report, cfg *Config) {
 	if len(report.Clusters) == 0 {
 		return
 	}
 
-	fmt.Println()
-	fmt.Println("Kubernetes Clusters")
-	fmt.Println("-------------------")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Kubernetes Clusters")
+	fmt.Fprintln(w, "-------------------")
 
 	for _, status := range report.Clusters {
-		fmt.Printf("  %s (v%s)\n", status.Cluster.Properties.Name, status.Cluster.Properties.K8sVersion)
-		fmt.Printf("    Node Pools: %d\n", len(status.NodePools))
+		fmt.Fprintf(w, "  %s (v%s)\n", status.Cluster.Properties.Name, status.Cluster.Properties.K8sVersion)
+		fmt.Fprintf(w, "    Node Pools: %d\n", len(status.NodePools))
 		if cfg.Verbose {
 			for _, np := range status.NodePools {
-				fmt.Printf("      - %s (%d nodes, %s)\n", np.Properties.Name, np.Properties.NodeCount, np.Metadata.State)
+				fmt.Fprintf(w, "      - %s (%d nodes, %s)\n", np.Properties.Name, np.Properties.NodeCount, np.Metadata.State)
 			}
 		}
 		if len(status.Issues) == 0 {
-			fmt.Println("    State: ACTIVE")
+			fmt.Fprintln(w, "    State: ACTIVE")
 		} else {
-			fmt.Println("    State: ISSUES")
+			fmt.Fprintln(w, "    State: ISSUES")
 		}
 	}
 }
 
-func printPostgreSQL(dbaas *ionos.DBaaSStatus, cfg *Config) {
+func printPostgreSQL(w *strings.Builder, dbaas *ionos.DBaaSStatus, cfg *Config) {
 	if len(dbaas.PostgreSQL) > 0 {
-		fmt.Printf("  PostgreSQL: %d cluster(s)\n", len(dbaas.PostgreSQL))
+		fmt.Fprintf(w, "  PostgreSQL: %d cluster(s)\n", len(dbaas.PostgreSQL))
 		if cfg.Verbose {
 			for _, cluster := range dbaas.PostgreSQL {
 				state := cluster.Metadata.State
-				fmt.Printf("    - %s (v%s, %s, %d instances, %s)\n",
+				fmt.Fprintf(w, "    - %s (v%s, %s, %d instances, %s)\n",
 					cluster.Properties.DisplayName,
 					cluster.Properties.PostgresVersion,
 					cluster.Properties.Location,
@@ -149,13 +165,13 @@ func printPostgreSQL(dbaas *ionos.DBaaSStatus, cfg *Config) {
 	}
 }
 
-func printMongoDB(dbaas *ionos.DBaaSStatus, cfg *Config) {
+func printMongoDB(w *strings.Builder, dbaas *ionos.DBaaSStatus, cfg *Config) {
 	if len(dbaas.MongoDB) > 0 {
-		fmt.Printf("  MongoDB: %d cluster(s)\n", len(dbaas.MongoDB))
+		fmt.Fprintf(w, "  MongoDB: %d cluster(s)\n", len(dbaas.MongoDB))
 		if cfg.Verbose {
 			for _, cluster := range dbaas.MongoDB {
 				state := cluster.Metadata.State
-				fmt.Printf("    - %s (v%s, %s, %d instances, %s)\n",
+				fmt.Fprintf(w, "    - %s (v%s, %s, %d instances, %s)\n",
 					cluster.Properties.DisplayName,
 					cluster.Properties.MongoDBVersion,
 					cluster.Properties.Location,
@@ -166,13 +182,13 @@ func printMongoDB(dbaas *ionos.DBaaSStatus, cfg *Config) {
 	}
 }
 
-func printMariaDB(dbaas *ionos.DBaaSStatus, cfg *Config) {
+func printMariaDB(w *strings.Builder, dbaas *ionos.DBaaSStatus, cfg *Config) {
 	if len(dbaas.MariaDB) > 0 {
-		fmt.Printf("  MariaDB: %d cluster(s)\n", len(dbaas.MariaDB))
+		fmt.Fprintf(w, "  MariaDB: %d cluster(s)\n", len(dbaas.MariaDB))
 		if cfg.Verbose {
 			for _, cluster := range dbaas.MariaDB {
 				state := cluster.Metadata.State
-				fmt.Printf("    - %s (v%s, %s, %d instances, %s)\n",
+				fmt.Fprintf(w, "    - %s (v%s, %s, %d instances, %s)\n",
 					cluster.Properties.DisplayName,
 					cluster.Properties.MariaDBVersion,
 					cluster.Properties.Location,
@@ -183,13 +199,13 @@ func printMariaDB(dbaas *ionos.DBaaSStatus, cfg *Config) {
 	}
 }
 
-func printInMemoryDB(dbaas *ionos.DBaaSStatus, cfg *Config) {
+func printInMemoryDB(w *strings.Builder, dbaas *ionos.DBaaSStatus, cfg *Config) {
 	if len(dbaas.InMemoryDB) > 0 {
-		fmt.Printf("  In-Memory DB: %d instance(s)\n", len(dbaas.InMemoryDB))
+		fmt.Fprintf(w, "  In-Memory DB: %d instance(s)\n", len(dbaas.InMemoryDB))
 		if cfg.Verbose {
 			for _, instance := range dbaas.InMemoryDB {
 				state := instance.Metadata.State
-				fmt.Printf("    - %s (v%s, %s, %d replicas, %s)\n",
+				fmt.Fprintf(w, "    - %s (v%s, %s, %d replicas, %s)\n",
 					instance.Properties.DisplayName,
 					instance.Properties.Version,
 					instance.Properties.Location,
@@ -200,7 +216,7 @@ func printInMemoryDB(dbaas *ionos.DBaaSStatus, cfg *Config) {
 	}
 }
 
-func printDBaaS(report *Report, cfg *Config) {
+func printDBaaS(w *strings.Builder, report *Report, cfg *Config) {
 	if report.DBaaS == nil {
 		return
 	}
@@ -212,14 +228,14 @@ func printDBaaS(report *Report, cfg *Config) {
 		return
 	}
 
-	fmt.Println()
-	fmt.Println("Managed Databases")
-	fmt.Println("-----------------")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Managed Databases")
+	fmt.Fprintln(w, "-----------------")
 
-	printPostgreSQL(dbaas, cfg)
-	printMongoDB(dbaas, cfg)
-	printMariaDB(dbaas, cfg)
-	printInMemoryDB(dbaas, cfg)
+	printPostgreSQL(w, dbaas, cfg)
+	printMongoDB(w, dbaas, cfg)
+	printMariaDB(w, dbaas, cfg)
+	printInMemoryDB(w, dbaas, cfg)
 
 	issueCount := 0
 	for _, cluster := range dbaas.PostgreSQL {
@@ -244,117 +260,99 @@ func printDBaaS(report *Report, cfg *Config) {
 	}
 
 	if issueCount == 0 {
-		fmt.Println("  State: OK")
+		fmt.Fprintln(w, "  State: OK")
 	} else {
-		fmt.Println("  State: ISSUES")
+		fmt.Fprintln(w, "  State: ISSUES")
 	}
 }
 
-func printHealth(report *Report) {
+func printHealth(w *strings.Builder, report *Report, cfg *Config) {
 	if report.Health == nil {
 		return
 	}
 
 	health := report.Health
 
-	fmt.Println()
-	fmt.Println("Health")
-	fmt.Println("------")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Health")
+	fmt.Fprintln(w, "------")
 
-	fmt.Printf("  %-14s %d/%d Ready\n", "Nodes", health.Nodes.Ready, health.Nodes.Total)
-	fmt.Printf("  %-14s %d/%d Running\n", "Pods", health.Pods.Running, health.Pods.Total)
-	fmt.Printf("  %-14s %d/%d Available\n", "Deployments", health.Deployments.Available, health.Deployments.Total)
+	fmt.Fprintf(w, "  %-14s %d/%d Ready\n", "Nodes", health.Nodes.Ready, health.Nodes.Total)
+	fmt.Fprintf(w, "  %-14s %d/%d Running\n", "Pods", health.Pods.Running, health.Pods.Total)
+	fmt.Fprintf(w, "  %-14s %d/%d Available\n", "Deployments", health.Deployments.Available, health.Deployments.Total)
 
-	if health.PVCs.Total > 0 {
-		fmt.Printf("  %-14s %d/%d Bound\n", "PVCs", health.PVCs.Bound, health.PVCs.Total)
+	if health.StatefulSets.Total > 0 {
+		fmt.Fprintf(w, "  %-14s %d/%d Ready\n", "StatefulSets", health.StatefulSets.Ready, health.StatefulSets.Total)
 	}
 
-	if health.Services.Total > 0 {
-		fmt.Printf("  %-14s %d/%d Ready\n", "LoadBalancers", health.Services.Ready, health.Services.Total)
+	if health.DaemonSets.Total > 0 {
+		fmt.Fprintf(w, "  %-14s %d/%d Ready\n", "DaemonSets", health.DaemonSets.Ready, health.DaemonSets.Total)
 	}
 
-	if health.Certs.Total > 0 {
-		fmt.Printf("  %-14s %d/%d Valid\n", "Certificates", health.Certs.Valid, health.Certs.Total)
+	if health.Jobs.Total > 0 {
+		fmt.Fprintf(w, "  %-14s %d/%d OK\n", "Jobs", health.Jobs.Total-len(health.Jobs.Failed), health.Jobs.Total)
 	}
-}
 
-func printIssues(report *Report) {
-	if len(report.Issues) == 0 {
-		return
+	if health.CronJobs.Total > 0 {
+		fmt.Fprintf(w, "  %-14s %d/%d On Schedule", "CronJobs", health.CronJobs.OnSchedule, health.CronJobs.Total)
+		if len(health.CronJobs.Suspended) > 0 {
+			fmt.Fprintf(w, " (%d suspended)", len(health.CronJobs.Suspended))
+		}
+		fmt.Fprintln(w)
 	}
 
-	health := report.Health
+	if health.HPAs.Total > 0 {
+		fmt.Fprintf(w, "  %-14s %d/%d OK\n", "HPAs", health.HPAs.Healthy, health.HPAs.Total)
+	}
 
-	fmt.Println()
-	fmt.Println("Issues")
-	fmt.Println("------")
-	for _, issue := range report.Issues {
-		fmt.Printf("  - %s\n", issue)
+	if health.PDBs.Total > 0 {
+		fmt.Fprintf(w, "  %-14s %d/%d Allow Disruptions\n", "PDBs", health.PDBs.Healthy, health.PDBs.Total)
 	}
 
-	if health != nil {
-		if len(health.Nodes.NotReady) > 0 {
-			fmt.Println()
-			fmt.Println("  Nodes NotReady:")
-			for _, node := range health.Nodes.NotReady {
-				fmt.Printf("    %s\n", node)
-			}
-		}
+	if health.PVCs.Total > 0 {
+		fmt.Fprintf(w, "  %-14s %d/%d Bound\n", "PVCs", health.PVCs.Bound, health.PVCs.Total)
+	}
 
-		if len(health.Pods.CrashLoopBackOff) > 0 {
-			fmt.Println()
-			fmt.Println("  Pods CrashLoopBackOff:")
-			for _, pod := range health.Pods.CrashLoopBackOff {
-				fmt.Printf("    %s\n", pod)
-			}
-		}
+	if health.Services.Total > 0 {
+		fmt.Fprintf(w, "  %-14s %d/%d Ready\n", "LoadBalancers", health.Services.Ready, health.Services.Total)
+	}
 
-		if len(health.Pods.Pending) > 0 {
-			fmt.Println()
-			fmt.Println("  Pods Pending:")
-			for _, pod := range health.Pods.Pending {
-				fmt.Printf("    %s\n", pod)
-			}
-		}
+	if health.Endpoints.Total > 0 {
+		fmt.Fprintf(w, "  %-14s %d/%d With Endpoints\n", "Services", health.Endpoints.Ready, health.Endpoints.Total)
+	}
 
-		if len(health.PVCs.Pending) > 0 {
-			fmt.Println()
-			fmt.Println("  PVCs Pending:")
-			for _, pvc := range health.PVCs.Pending {
-				fmt.Printf("    %s\n", pvc)
-			}
-		}
+	if health.Endpoints.Ingresses > 0 {
+		fmt.Fprintf(w, "  %-14s %d/%d Routable\n", "Ingresses", health.Endpoints.IngressesReady, health.Endpoints.Ingresses)
+	}
 
-		if len(health.Deployments.Unavailable) > 0 {
-			fmt.Println()
-			fmt.Println("  Deployments Unavailable:")
-			for _, deploy := range health.Deployments.Unavailable {
-				fmt.Printf("    %s\n", deploy)
-			}
-		}
+	if health.Certs.Total > 0 {
+		fmt.Fprintf(w, "  %-14s %d/%d Valid\n", "Certificates", health.Certs.Valid, health.Certs.Total)
+	}
 
-		if len(health.Services.NoIP) > 0 {
-			fmt.Println()
-			fmt.Println("  LoadBalancers NoIP:")
-			for _, svc := range health.Services.NoIP {
-				fmt.Printf("    %s\n", svc)
+	if len(health.Events.Groups) > 0 {
+		fmt.Fprintf(w, "  %-14s %d\n", "Warning Events", len(health.Events.Groups))
+		if cfg.Verbose {
+			for _, group := range health.Events.Groups {
+				fmt.Fprintf(w, "      - %dx %s %s %s (last seen %s): %s\n",
+					group.Count, group.Reason, group.Kind, group.Object(),
+					group.LastSeen.Local().Format("2006-01-02 15:04:05"), group.Message)
 			}
 		}
+	}
+}
 
-		if len(health.Certs.Expired) > 0 {
-			fmt.Println()
-			fmt.Println("  Certificates Expired:")
-			for _, cert := range health.Certs.Expired {
-				fmt.Printf("    %s (%s)\n", cert.Host, cert.Secret)
-			}
-		}
+func printIssues(w *strings.Builder, report *Report, cfg *Config) {
+	if len(report.Issues) == 0 {
+		return
+	}
 
-		if len(health.Certs.Expiring) > 0 {
-			fmt.Println()
-			fmt.Println("  Certificates Expiring:")
-			for _, cert := range health.Certs.Expiring {
-				fmt.Printf("    %s (%d days)\n", cert.Host, cert.ExpiresIn)
-			}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Issues")
+	fmt.Fprintln(w, "------")
+	for _, issue := range report.Issues {
+		fmt.Fprintf(w, "  - [%s] %s\n", strings.ToUpper(string(issue.Severity)), issue.Message)
+		if cfg.Verbose && issue.Hint != "" {
+			fmt.Fprintf(w, "      Hint: %s\n", issue.Hint)
 		}
 	}
 }
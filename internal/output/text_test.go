@@ -21,7 +21,11 @@ func TestPrintText_Basic(t *testing.T) {
 		},
 		APICheck:  &ionos.CheckResult{OK: false},
 		AuthCheck: &ionos.CheckResult{OK: true},
-		Issues:    []string{"Issue one"},
+		Issues: []Issue{{
+			Severity: SeverityWarning,
+			Message:  "Issue one",
+			Hint:     "Do something",
+		}},
 	}
 
 	out := captureOutput(t, func() {
@@ -32,8 +36,11 @@ func TestPrintText_Basic(t *testing.T) {
 	expectContains(t, out, "Status Page")
 	expectContains(t, out, "Incident A")
 	expectContains(t, out, "API")
-	expectContains(t, out, "- Issue one")
+	expectContains(t, out, "- [WARNING] Issue one")
 	expectContains(t, out, "Status: WARNING")
+	if strings.Contains(out, "Hint:") {
+		t.Fatalf("expected hints only in verbose mode\n%s", out)
+	}
 }
 
 func TestPrintText_VerboseSections(t *testing.T) {
@@ -72,7 +79,7 @@ func TestPrintText_VerboseSections(t *testing.T) {
 					State string "json:\"state\""
 				}{State: "AVAILABLE"},
 			}},
-			Issues: []string{"Server issue"},
+			Issues: []ionos.ResourceIssue{{Message: "Server issue"}},
 		}},
 		Clusters: []ionos.K8sClusterStatus{{
 			Cluster: ionos.K8sCluster{
@@ -92,7 +99,7 @@ func TestPrintText_VerboseSections(t *testing.T) {
 					State string "json:\"state\""
 				}{State: "ACTIVE"},
 			}},
-			Issues: []string{"Cluster issue"},
+			Issues: []ionos.ResourceIssue{{Message: "Cluster issue"}},
 		}},
 		Health: &k8s.HealthResult{
 			Nodes: k8s.NodeResult{
@@ -111,6 +118,11 @@ func TestPrintText_VerboseSections(t *testing.T) {
 				Total:       1,
 				Unavailable: []string{"ns/deploy"},
 			},
+			StatefulSets: k8s.StatefulSetResult{
+				Ready:        0,
+				Total:        1,
+				RolloutStuck: []string{"ns/db"},
+			},
 			PVCs: k8s.PVCResult{
 				Bound:   1,
 				Total:   2,
@@ -126,8 +138,16 @@ func TestPrintText_VerboseSections(t *testing.T) {
 				Valid:   0,
 				Expired: []k8s.CertInfo{{Host: "old.example.com", Secret: "s1"}},
 			},
+			Events: k8s.EventResult{Groups: []k8s.EventGroup{
+				{Reason: "FailedMount", Kind: "Pod", Namespace: "ns", Name: "pod2", Message: "timed out", Count: 3},
+			}},
+		},
+		Issues: []Issue{
+			{Severity: SeverityWarning, Message: "DC DC1: Server issue"},
+			{Severity: SeverityWarning, Message: "Cluster cluster: Cluster issue"},
+			{Severity: SeverityCritical, Message: "Node node-2 NotReady", Hint: "Check the node pool"},
+			{Severity: SeverityWarning, Message: "Pod ns/pod1 CrashLoopBackOff"},
 		},
-		Issues: []string{"Server issue", "Cluster issue", "2 node issues", "2 pod issues"},
 	}
 
 	out := captureOutput(t, func() {
@@ -139,12 +159,12 @@ func TestPrintText_VerboseSections(t *testing.T) {
 	expectContains(t, out, "vol1 (10GB HDD)")
 	expectContains(t, out, "Kubernetes Clusters")
 	expectContains(t, out, "pool1 (3 nodes")
-	expectContains(t, out, "Nodes NotReady:")
-	expectContains(t, out, "Pods CrashLoopBackOff:")
-	expectContains(t, out, "Pods Pending:")
-	expectContains(t, out, "Deployments Unavailable:")
-	expectContains(t, out, "LoadBalancers NoIP:")
-	expectContains(t, out, "Certificates Expired:")
+	expectContains(t, out, "- [CRITICAL] Node node-2 NotReady")
+	expectContains(t, out, "Hint: Check the node pool")
+	expectContains(t, out, "- [WARNING] Pod ns/pod1 CrashLoopBackOff")
+	expectContains(t, out, "StatefulSets   0/1 Ready")
+	expectContains(t, out, "Warning Events 1")
+	expectContains(t, out, "- 3x FailedMount Pod ns/pod2 (last seen")
 	expectContains(t, out, "Status: CRITICAL")
 }
 